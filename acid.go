@@ -0,0 +1,168 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// AcidOneShot flags a file played once instead of looped.
+	AcidOneShot uint32 = 0x01
+	// AcidRootNoteSet flags the root note as valid.
+	AcidRootNoteSet uint32 = 0x02
+	// AcidStretch flags a file stretched to the project tempo.
+	AcidStretch uint32 = 0x04
+	// AcidDiskBased flags a file streamed from disk instead of loaded to memory.
+	AcidDiskBased uint32 = 0x08
+	// AcidHighOctave flags the root note an octave higher (ACIDizer setting).
+	AcidHighOctave uint32 = 0x10
+	// acidSize is the data size of an acid chunk.
+	acidSize = 24
+)
+
+// Acid is RIFF chunk 'acid' containing loop metadata as written by ACID and compatible applications.
+type Acid struct {
+	*Header
+	flags            uint32
+	rootNote         uint16
+	reserved1        uint16
+	reserved2        float32
+	numBeats         uint32
+	meterDenominator uint16
+	meterNumerator   uint16
+	tempo            float32
+}
+
+// Flags is a combination of AcidOneShot, AcidRootNoteSet, AcidStretch, AcidDiskBased and AcidHighOctave.
+func (a *Acid) Flags() uint32 {
+	return a.flags
+}
+
+// SetFlags
+func (a *Acid) SetFlags(value uint32) {
+	a.flags = value
+}
+
+// IsOneShot returns true if flag AcidOneShot is set.
+func (a *Acid) IsOneShot() bool {
+	return a.flags&AcidOneShot != 0
+}
+
+// IsStretch returns true if flag AcidStretch is set.
+func (a *Acid) IsStretch() bool {
+	return a.flags&AcidStretch != 0
+}
+
+// RootNote is the MIDI root note, valid if flag AcidRootNoteSet is set.
+func (a *Acid) RootNote() int {
+	return int(a.rootNote)
+}
+
+// SetRootNote sets the MIDI root note and flag AcidRootNoteSet.
+func (a *Acid) SetRootNote(value int) {
+	a.rootNote = uint16(value)
+	a.flags |= AcidRootNoteSet
+}
+
+// NumBeats is the number of beats.
+func (a *Acid) NumBeats() int {
+	return int(a.numBeats)
+}
+
+// SetNumBeats
+func (a *Acid) SetNumBeats(value int) {
+	a.numBeats = uint32(value)
+}
+
+// Meter returns numerator and denominator of the meter, e.g. 4 / 4.
+func (a *Acid) Meter() (int, int) {
+	return int(a.meterNumerator), int(a.meterDenominator)
+}
+
+// SetMeter sets numerator and denominator of the meter.
+func (a *Acid) SetMeter(numerator int, denominator int) {
+	a.meterNumerator = uint16(numerator)
+	a.meterDenominator = uint16(denominator)
+}
+
+// Tempo is the tempo in beats per minute.
+func (a *Acid) Tempo() float32 {
+	return a.tempo
+}
+
+// SetTempo
+func (a *Acid) SetTempo(value float32) {
+	a.tempo = value
+}
+
+// String returns string represensation of chunk.
+func (a *Acid) String() string {
+	numerator, denominator := a.Meter()
+
+	return fmt.Sprintf("Flags: %05b\nRoot note: %d\nBeats: %d\nMeter: %d/%d\nTempo: %.3f", a.Flags(), a.RootNote(), a.NumBeats(), numerator, denominator, a.Tempo())
+}
+
+// Bytes converts Acid to byte array. A new Header with id 'acid' is created.
+//
+// Header size is set to real data size. An amount of 32 bytes is returned.
+// chunk header - 8 bytes
+// flags - 4 bytes
+// root note - 2 bytes
+// reserved - 6 bytes
+// number of beats - 4 bytes
+// meter denominator - 2 bytes
+// meter numerator - 2 bytes
+// tempo - 4 bytes (32 bit float)
+func (a *Acid) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	data := make([]byte, acidSize)
+	byteOrder.PutUint32(data[0:4], a.flags)
+	byteOrder.PutUint16(data[4:6], a.rootNote)
+	byteOrder.PutUint16(data[6:8], a.reserved1)
+	byteOrder.PutUint32(data[8:12], math.Float32bits(a.reserved2))
+	byteOrder.PutUint32(data[12:16], a.numBeats)
+	byteOrder.PutUint16(data[16:18], a.meterDenominator)
+	byteOrder.PutUint16(data[18:20], a.meterNumerator)
+	byteOrder.PutUint32(data[20:24], math.Float32bits(a.tempo))
+	header := EncodeChunkHeader(CreateFourCC(ACIDID), uint32(len(data)), byteOrder)
+
+	return append(header.Bytes(), data...)
+}
+
+// EncodeAcidChunk returns encoded chunk 'acid' by provided parameters.
+func EncodeAcidChunk(flags uint32, rootNote uint16, numBeats uint32, meterNumerator uint16, meterDenominator uint16, tempo float32) *Acid {
+	header := EncodeChunkHeader(CreateFourCC(ACIDID), acidSize, binary.LittleEndian)
+
+	return &Acid{Header: header, flags: flags, rootNote: rootNote, numBeats: numBeats, meterNumerator: meterNumerator, meterDenominator: meterDenominator, tempo: tempo}
+}
+
+// DecodeAcidChunk decodes provided byte array to Acid.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// data - 24 bytes
+func DecodeAcidChunk(data []byte) (*Acid, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	a := &Acid{}
+	byteOrder := binary.LittleEndian
+	a.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	fields := []interface{}{&a.flags, &a.rootNote, &a.reserved1, &a.reserved2, &a.numBeats, &a.meterDenominator, &a.meterNumerator, &a.tempo}
+
+	for _, f := range fields {
+		err := binary.Read(buf, byteOrder, f)
+
+		if err != nil {
+			return a, err
+		}
+	}
+
+	return a, nil
+}
@@ -0,0 +1,41 @@
+package chunk
+
+import "testing"
+
+func TestEncodeAcidChunk(t *testing.T) {
+	chunk := EncodeAcidChunk(AcidStretch, 0, 8, 4, 4, 120)
+
+	assertEqual(t, chunk.ID(), ACIDID, "ID")
+	assertEqual(t, chunk.Size(), uint32(24), "Size")
+	assertEqual(t, len(chunk.Bytes()), 32, "Bytes length")
+	assertEqual(t, chunk.IsStretch(), true, "IsStretch")
+	assertEqual(t, chunk.IsOneShot(), false, "IsOneShot")
+
+	chunk.SetRootNote(57)
+
+	assertEqual(t, chunk.Flags(), AcidStretch|AcidRootNoteSet, "Flags")
+}
+
+func TestDecodeAcidChunk(t *testing.T) {
+	data := EncodeAcidChunk(AcidOneShot|AcidRootNoteSet, 60, 16, 3, 4, 97.5).Bytes()
+	chunk, err := DecodeAcidChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, chunk.IsOneShot(), true, "IsOneShot")
+	assertEqual(t, chunk.RootNote(), 60, "RootNote")
+	assertEqual(t, chunk.NumBeats(), 16, "NumBeats")
+
+	numerator, denominator := chunk.Meter()
+
+	assertEqual(t, numerator, 3, "Meter numerator")
+	assertEqual(t, denominator, 4, "Meter denominator")
+	assertEqual(t, chunk.Tempo(), float32(97.5), "Tempo")
+
+	_, err = DecodeAcidChunk(data[:HeaderSizeBytes+22])
+
+	assertNotNil(t, err, "err with short data")
+
+	_, err = DecodeAcidChunk(data[:HeaderSizeBytes-1])
+
+	assertNotNil(t, err, "err with short header")
+}
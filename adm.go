@@ -0,0 +1,293 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+const (
+	// admVersion is the version of audioFormatExtended written by EncodeADM.
+	admVersion = "ITU-R_BS.2076-2"
+	// ebuCoreNamespace is the namespace of the root element written by EncodeADM.
+	ebuCoreNamespace = "urn:ebu:metadata-schema:ebuCore_2016"
+)
+
+// ADM is the Audio Definition Model (ITU-R BS.2076) carried by chunk 'axml' or 'bxml'.
+// Only the listed elements and attributes are modelled, use the XML of the chunk for further elements.
+type ADM struct {
+	Version        string              `xml:"version,attr,omitempty"`
+	Programmes     []*ADMProgramme     `xml:"audioProgramme"`
+	Contents       []*ADMContent       `xml:"audioContent"`
+	Objects        []*ADMObject        `xml:"audioObject"`
+	PackFormats    []*ADMPackFormat    `xml:"audioPackFormat"`
+	ChannelFormats []*ADMChannelFormat `xml:"audioChannelFormat"`
+	StreamFormats  []*ADMStreamFormat  `xml:"audioStreamFormat"`
+	TrackFormats   []*ADMTrackFormat   `xml:"audioTrackFormat"`
+	TrackUIDs      []*ADMTrackUID      `xml:"audioTrackUID"`
+}
+
+// ADMProgramme is element audioProgramme, a complete mix of contents.
+type ADMProgramme struct {
+	ID          string          `xml:"audioProgrammeID,attr"`
+	Name        string          `xml:"audioProgrammeName,attr,omitempty"`
+	Language    string          `xml:"audioProgrammeLanguage,attr,omitempty"`
+	Start       string          `xml:"start,attr,omitempty"`
+	End         string          `xml:"end,attr,omitempty"`
+	ContentRefs []string        `xml:"audioContentIDRef"`
+	Loudness    *ADMLoudness    `xml:"loudnessMetadata,omitempty"`
+	Labels      []*ADMTextLabel `xml:"audioProgrammeLabel"`
+}
+
+// ADMContent is element audioContent, a component of a programme like dialogue or music.
+type ADMContent struct {
+	ID         string          `xml:"audioContentID,attr"`
+	Name       string          `xml:"audioContentName,attr,omitempty"`
+	Language   string          `xml:"audioContentLanguage,attr,omitempty"`
+	ObjectRefs []string        `xml:"audioObjectIDRef"`
+	Loudness   *ADMLoudness    `xml:"loudnessMetadata,omitempty"`
+	Dialogue   *ADMDialogue    `xml:"dialogue,omitempty"`
+	Labels     []*ADMTextLabel `xml:"audioContentLabel"`
+}
+
+// ADMObject is element audioObject, linking pack formats and track UIDs of a content.
+type ADMObject struct {
+	ID             string          `xml:"audioObjectID,attr"`
+	Name           string          `xml:"audioObjectName,attr,omitempty"`
+	Start          string          `xml:"start,attr,omitempty"`
+	Duration       string          `xml:"duration,attr,omitempty"`
+	Interact       string          `xml:"interact,attr,omitempty"`
+	ObjectRefs     []string        `xml:"audioObjectIDRef"`
+	PackFormatRefs []string        `xml:"audioPackFormatIDRef"`
+	TrackUIDRefs   []string        `xml:"audioTrackUIDRef"`
+	Labels         []*ADMTextLabel `xml:"audioObjectLabel"`
+}
+
+// ADMPackFormat is element audioPackFormat, a group of channel formats like a 5.1 layout.
+type ADMPackFormat struct {
+	ID                string   `xml:"audioPackFormatID,attr"`
+	Name              string   `xml:"audioPackFormatName,attr,omitempty"`
+	TypeLabel         string   `xml:"typeLabel,attr,omitempty"`
+	TypeDefinition    string   `xml:"typeDefinition,attr,omitempty"`
+	ChannelFormatRefs []string `xml:"audioChannelFormatIDRef"`
+	PackFormatRefs    []string `xml:"audioPackFormatIDRef"`
+}
+
+// ADMChannelFormat is element audioChannelFormat, a single channel like a speaker feed or an object.
+type ADMChannelFormat struct {
+	ID             string            `xml:"audioChannelFormatID,attr"`
+	Name           string            `xml:"audioChannelFormatName,attr,omitempty"`
+	TypeLabel      string            `xml:"typeLabel,attr,omitempty"`
+	TypeDefinition string            `xml:"typeDefinition,attr,omitempty"`
+	Blocks         []*ADMBlockFormat `xml:"audioBlockFormat"`
+}
+
+// ADMBlockFormat is element audioBlockFormat, the time dependent parameters of a channel format.
+type ADMBlockFormat struct {
+	ID            string         `xml:"audioBlockFormatID,attr"`
+	RTime         string         `xml:"rtime,attr,omitempty"`
+	Duration      string         `xml:"duration,attr,omitempty"`
+	SpeakerLabels []string       `xml:"speakerLabel"`
+	Positions     []*ADMPosition `xml:"position"`
+	Gain          string         `xml:"gain,omitempty"`
+}
+
+// ADMPosition is element position of a block format.
+type ADMPosition struct {
+	// Coordinate is 'azimuth', 'elevation', 'distance' or 'X', 'Y', 'Z'.
+	Coordinate     string `xml:"coordinate,attr"`
+	Bound          string `xml:"bound,attr,omitempty"`
+	ScreenEdgeLock string `xml:"screenEdgeLock,attr,omitempty"`
+	Value          string `xml:",chardata"`
+}
+
+// ADMStreamFormat is element audioStreamFormat, the format of a stream of track formats.
+type ADMStreamFormat struct {
+	ID                string   `xml:"audioStreamFormatID,attr"`
+	Name              string   `xml:"audioStreamFormatName,attr,omitempty"`
+	FormatLabel       string   `xml:"formatLabel,attr,omitempty"`
+	FormatDefinition  string   `xml:"formatDefinition,attr,omitempty"`
+	ChannelFormatRefs []string `xml:"audioChannelFormatIDRef"`
+	PackFormatRefs    []string `xml:"audioPackFormatIDRef"`
+	TrackFormatRefs   []string `xml:"audioTrackFormatIDRef"`
+}
+
+// ADMTrackFormat is element audioTrackFormat, the format of a track.
+type ADMTrackFormat struct {
+	ID               string `xml:"audioTrackFormatID,attr"`
+	Name             string `xml:"audioTrackFormatName,attr,omitempty"`
+	FormatLabel      string `xml:"formatLabel,attr,omitempty"`
+	FormatDefinition string `xml:"formatDefinition,attr,omitempty"`
+	StreamFormatRef  string `xml:"audioStreamFormatIDRef,omitempty"`
+}
+
+// ADMTrackUID is element audioTrackUID, a track of the file referenced by chunk 'chna'.
+type ADMTrackUID struct {
+	UID              string `xml:"UID,attr"`
+	SampleRate       string `xml:"sampleRate,attr,omitempty"`
+	BitDepth         string `xml:"bitDepth,attr,omitempty"`
+	TrackFormatRef   string `xml:"audioTrackFormatIDRef,omitempty"`
+	ChannelFormatRef string `xml:"audioChannelFormatIDRef,omitempty"`
+	PackFormatRef    string `xml:"audioPackFormatIDRef,omitempty"`
+}
+
+// ADMLoudness is element loudnessMetadata.
+type ADMLoudness struct {
+	Method             string `xml:"loudnessMethod,attr,omitempty"`
+	RecType            string `xml:"loudnessRecType,attr,omitempty"`
+	CorrectionType     string `xml:"loudnessCorrectionType,attr,omitempty"`
+	IntegratedLoudness string `xml:"integratedLoudness,omitempty"`
+	LoudnessRange      string `xml:"loudnessRange,omitempty"`
+	MaxTruePeak        string `xml:"maxTruePeak,omitempty"`
+	MaxMomentary       string `xml:"maxMomentary,omitempty"`
+	MaxShortTerm       string `xml:"maxShortTerm,omitempty"`
+	DialogueLoudness   string `xml:"dialogueLoudness,omitempty"`
+}
+
+// ADMDialogue is element dialogue of a content: 0 no dialogue, 1 dialogue, 2 mixed.
+type ADMDialogue struct {
+	Kind  string `xml:"nonDialogueContentKind,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// ADMTextLabel is a label element of a programme, content or object in a language.
+type ADMTextLabel struct {
+	Language string `xml:"language,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+// Programme returns the programme of provided id, nil if not found.
+func (a *ADM) Programme(id string) *ADMProgramme {
+	for _, p := range a.Programmes {
+		if p.ID == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Content returns the content of provided id, nil if not found.
+func (a *ADM) Content(id string) *ADMContent {
+	for _, c := range a.Contents {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Object returns the object of provided id, nil if not found.
+func (a *ADM) Object(id string) *ADMObject {
+	for _, o := range a.Objects {
+		if o.ID == id {
+			return o
+		}
+	}
+
+	return nil
+}
+
+// PackFormat returns the pack format of provided id, nil if not found.
+func (a *ADM) PackFormat(id string) *ADMPackFormat {
+	for _, p := range a.PackFormats {
+		if p.ID == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// ChannelFormat returns the channel format of provided id, nil if not found.
+func (a *ADM) ChannelFormat(id string) *ADMChannelFormat {
+	for _, c := range a.ChannelFormats {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// TrackUID returns the track UID of provided UID, nil if not found.
+func (a *ADM) TrackUID(uid string) *ADMTrackUID {
+	for _, t := range a.TrackUIDs {
+		if t.UID == uid {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// String returns string represensation of ADM.
+func (a *ADM) String() string {
+	return fmt.Sprintf("Version: %s\nProgrammes: %d\nContents: %d\nObjects: %d\nPack formats: %d\nChannel formats: %d\nStream formats: %d\nTrack formats: %d\nTrack UIDs: %d",
+		a.Version, len(a.Programmes), len(a.Contents), len(a.Objects), len(a.PackFormats), len(a.ChannelFormats), len(a.StreamFormats), len(a.TrackFormats), len(a.TrackUIDs))
+}
+
+// ebuCoreMain is the root element of an ADM document as specified in ITU-R BS.2076.
+type ebuCoreMain struct {
+	XMLName  xml.Name `xml:"ebuCoreMain"`
+	Xmlns    string   `xml:"xmlns,attr,omitempty"`
+	Extended *ADM     `xml:"coreMetadata>format>audioFormatExtended"`
+}
+
+// EncodeADM encodes provided ADM to an XML document with root element ebuCoreMain.
+// Version ITU-R_BS.2076-2 is set if no version is present.
+func EncodeADM(adm *ADM) ([]byte, error) {
+	extended := *adm
+
+	if extended.Version == "" {
+		extended.Version = admVersion
+	}
+
+	data, err := xml.MarshalIndent(ebuCoreMain{Xmlns: ebuCoreNamespace, Extended: &extended}, "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// DecodeADM decodes provided XML document with root element ebuCoreMain or audioFormatExtended to ADM.
+func DecodeADM(data []byte) (*ADM, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimRight(data, "\x00")))
+
+	for {
+		token, err := decoder.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		if start.Name.Local == "audioFormatExtended" {
+			adm := &ADM{}
+			err = decoder.DecodeElement(adm, &start)
+
+			return adm, err
+		}
+
+		root := &ebuCoreMain{}
+		err = decoder.DecodeElement(root, &start)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if root.Extended == nil {
+			return &ADM{}, nil
+		}
+
+		return root.Extended, nil
+	}
+}
@@ -0,0 +1,84 @@
+package chunk
+
+import (
+	"strings"
+	"testing"
+)
+
+const testADM = `<?xml version="1.0" encoding="UTF-8"?>
+<ebuCoreMain xmlns="urn:ebu:metadata-schema:ebuCore_2016">
+  <coreMetadata>
+    <format>
+      <audioFormatExtended version="ITU-R_BS.2076-2">
+        <audioProgramme audioProgrammeID="APR_1001" audioProgrammeName="Main" audioProgrammeLanguage="en">
+          <audioContentIDRef>ACO_1001</audioContentIDRef>
+          <loudnessMetadata loudnessMethod="ITU-R BS.1770">
+            <integratedLoudness>-23.0</integratedLoudness>
+          </loudnessMetadata>
+        </audioProgramme>
+        <audioContent audioContentID="ACO_1001" audioContentName="Bed">
+          <audioObjectIDRef>AO_1001</audioObjectIDRef>
+          <dialogue nonDialogueContentKind="1">0</dialogue>
+        </audioContent>
+        <audioObject audioObjectID="AO_1001" audioObjectName="Stereo">
+          <audioPackFormatIDRef>AP_00010002</audioPackFormatIDRef>
+          <audioTrackUIDRef>ATU_00000001</audioTrackUIDRef>
+          <audioTrackUIDRef>ATU_00000002</audioTrackUIDRef>
+        </audioObject>
+        <audioPackFormat audioPackFormatID="AP_00031001" audioPackFormatName="Object" typeLabel="0003" typeDefinition="Objects">
+          <audioChannelFormatIDRef>AC_00031001</audioChannelFormatIDRef>
+        </audioPackFormat>
+        <audioChannelFormat audioChannelFormatID="AC_00031001" audioChannelFormatName="Object" typeLabel="0003" typeDefinition="Objects">
+          <audioBlockFormat audioBlockFormatID="AB_00031001_00000001" rtime="00:00:00.00000" duration="00:00:01.00000">
+            <position coordinate="azimuth">30.0</position>
+            <position coordinate="elevation">0.0</position>
+          </audioBlockFormat>
+        </audioChannelFormat>
+        <audioTrackUID UID="ATU_00000001" sampleRate="48000" bitDepth="24">
+          <audioTrackFormatIDRef>AT_00010001_01</audioTrackFormatIDRef>
+          <audioPackFormatIDRef>AP_00010002</audioPackFormatIDRef>
+        </audioTrackUID>
+        <audioTrackUID UID="ATU_00000002" sampleRate="48000" bitDepth="24"/>
+      </audioFormatExtended>
+    </format>
+  </coreMetadata>
+</ebuCoreMain>`
+
+func TestDecodeADM(t *testing.T) {
+	adm, err := DecodeADM([]byte(testADM))
+
+	assertNil(t, err, "err")
+	assertEqual(t, adm.Version, "ITU-R_BS.2076-2", "Version")
+	assertEqual(t, len(adm.Programmes), 1, "Programmes length")
+	assertEqual(t, adm.Programme("APR_1001").Loudness.IntegratedLoudness, "-23.0", "IntegratedLoudness")
+	assertEqual(t, adm.Content("ACO_1001").Dialogue.Value, "0", "Dialogue")
+	assertEqual(t, strings.Join(adm.Object("AO_1001").TrackUIDRefs, ","), "ATU_00000001,ATU_00000002", "TrackUIDRefs")
+	assertEqual(t, adm.PackFormat("AP_00031001").TypeDefinition, "Objects", "TypeDefinition")
+	assertEqual(t, adm.ChannelFormat("AC_00031001").Blocks[0].Positions[0].Value, "30.0", "Position")
+	assertEqual(t, adm.TrackUID("ATU_00000001").TrackFormatRef, "AT_00010001_01", "TrackFormatRef")
+	assertEqual(t, adm.Object("AO_1002"), (*ADMObject)(nil), "Object not found")
+
+	adm, err = DecodeADM([]byte(`<audioFormatExtended><audioObject audioObjectID="AO_1001"/></audioFormatExtended>`))
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(adm.Objects), 1, "Objects length without ebuCoreMain")
+
+	_, err = DecodeADM([]byte("<ebuCoreMain>"))
+
+	assertNotNil(t, err, "err with invalid XML")
+}
+
+func TestEncodeADM(t *testing.T) {
+	adm := &ADM{Objects: []*ADMObject{{ID: "AO_1001", Name: "Dialogue", TrackUIDRefs: []string{"ATU_00000001"}}}}
+	data, err := EncodeADM(adm)
+
+	assertNil(t, err, "err")
+	assertEqual(t, strings.Contains(string(data), `<ebuCoreMain xmlns="urn:ebu:metadata-schema:ebuCore_2016">`), true, "root element")
+	assertEqual(t, adm.Version, "", "Version of provided ADM unchanged")
+
+	decoded, err := DecodeADM(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Version, "ITU-R_BS.2076-2", "Version")
+	assertEqual(t, decoded.Object("AO_1001").Name, "Dialogue", "Name")
+}
@@ -0,0 +1,256 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// labelID is the id of a label sub chunk
+	labelID = "labl"
+	// noteID is the id of a note sub chunk
+	noteID = "note"
+	// labeledTextID is the id of a labeled text sub chunk
+	labeledTextID = "ltxt"
+	// labeledTextSize is the byte size of a labeled text sub chunk without text
+	labeledTextSize = 20
+)
+
+// Label is a text assigned to a cue point, used for sub chunks label 'labl' and note 'note'.
+type Label struct {
+	CuePointID uint32
+	Text       string
+}
+
+// String returns string represensation of label.
+func (l *Label) String() string {
+	return fmt.Sprintf("Cue point ID: %d Text: %s", l.CuePointID, l.Text)
+}
+
+// LabeledText is sub chunk 'ltxt' assigning a text and a length in sample frames to a cue point, defining a region.
+type LabeledText struct {
+	CuePointID   uint32
+	SampleLength uint32
+	Purpose      string
+	Country      uint16
+	Language     uint16
+	Dialect      uint16
+	CodePage     uint16
+	Text         string
+}
+
+// String returns string represensation of labeled text.
+func (t *LabeledText) String() string {
+	return fmt.Sprintf("Cue point ID: %d Sample length: %d Purpose: %s Country: %d Language: %d Dialect: %d Code page: %d Text: %s",
+		t.CuePointID, t.SampleLength, t.Purpose, t.Country, t.Language, t.Dialect, t.CodePage, t.Text)
+}
+
+// Adtl is RIFF list chunk 'LIST' of type 'adtl' containing associated data of cue points:
+// labels 'labl', notes 'note' and labeled texts 'ltxt'. Unknown sub chunks are preserved.
+type Adtl struct {
+	*Header
+	labels       []*Label
+	notes        []*Label
+	labeledTexts []*LabeledText
+	unknown      []*subChunk
+}
+
+// Labels returns the labels 'labl'.
+func (a *Adtl) Labels() []*Label {
+	return a.labels
+}
+
+// SetLabels sets the labels 'labl'.
+func (a *Adtl) SetLabels(labels []*Label) {
+	a.labels = labels
+}
+
+// Notes returns the notes 'note'.
+func (a *Adtl) Notes() []*Label {
+	return a.notes
+}
+
+// SetNotes sets the notes 'note'.
+func (a *Adtl) SetNotes(notes []*Label) {
+	a.notes = notes
+}
+
+// LabeledTexts returns the labeled texts 'ltxt'.
+func (a *Adtl) LabeledTexts() []*LabeledText {
+	return a.labeledTexts
+}
+
+// SetLabeledTexts sets the labeled texts 'ltxt'.
+func (a *Adtl) SetLabeledTexts(labeledTexts []*LabeledText) {
+	a.labeledTexts = labeledTexts
+}
+
+// Label returns the label of provided cue point id, nil if not found.
+func (a *Adtl) Label(cuePointID uint32) *Label {
+	return findLabel(a.labels, cuePointID)
+}
+
+// Note returns the note of provided cue point id, nil if not found.
+func (a *Adtl) Note(cuePointID uint32) *Label {
+	return findLabel(a.notes, cuePointID)
+}
+
+// LabeledText returns the labeled text of provided cue point id, nil if not found.
+func (a *Adtl) LabeledText(cuePointID uint32) *LabeledText {
+	for _, t := range a.labeledTexts {
+		if t.CuePointID == cuePointID {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// String returns string represensation of chunk.
+func (a *Adtl) String() string {
+	var lines []string
+
+	for _, l := range a.labels {
+		lines = append(lines, fmt.Sprintf("%s: %s", labelID, l))
+	}
+
+	for _, n := range a.notes {
+		lines = append(lines, fmt.Sprintf("%s: %s", noteID, n))
+	}
+
+	for _, t := range a.labeledTexts {
+		lines = append(lines, fmt.Sprintf("%s: %s", labeledTextID, t))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Bytes converts Adtl to byte array. A new Header with id 'LIST' is created.
+//
+// Header size is set to real data size. A minimum amount of 12 bytes is returned.
+// chunk header - 8 bytes
+// list type 'adtl' - 4 bytes
+// sub chunks - labels, notes, labeled texts and unknown sub chunks, each padded to even size
+func (a *Adtl) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	var entries []*subChunk
+
+	for _, l := range a.labels {
+		entries = append(entries, encodeLabel(labelID, l))
+	}
+
+	for _, n := range a.notes {
+		entries = append(entries, encodeLabel(noteID, n))
+	}
+
+	for _, t := range a.labeledTexts {
+		data := make([]byte, labeledTextSize)
+		purpose := CreateFourCC(t.Purpose)
+		byteOrder.PutUint32(data[0:4], t.CuePointID)
+		byteOrder.PutUint32(data[4:8], t.SampleLength)
+		copy(data[8:12], purpose[:])
+		byteOrder.PutUint16(data[12:14], t.Country)
+		byteOrder.PutUint16(data[14:16], t.Language)
+		byteOrder.PutUint16(data[16:18], t.Dialect)
+		byteOrder.PutUint16(data[18:20], t.CodePage)
+
+		if t.Text != "" {
+			data = append(data, terminate(t.Text, len(t.Text)+1)...)
+		}
+
+		entries = append(entries, &subChunk{id: labeledTextID, data: data})
+	}
+
+	entries = append(entries, a.unknown...)
+
+	return encodeList(ADTLID, entries)
+}
+
+// EncodeAdtlChunk returns encoded chunk 'LIST' of type 'adtl' containing provided labels, notes and labeled texts.
+func EncodeAdtlChunk(labels []*Label, notes []*Label, labeledTexts []*LabeledText) *Adtl {
+	a := &Adtl{labels: labels, notes: notes, labeledTexts: labeledTexts}
+	a.Header = decodeChunkHeader(a.Bytes(), 0, binary.LittleEndian)
+
+	return a
+}
+
+// DecodeAdtlChunk decodes provided byte array to Adtl.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// list type 'adtl' - 4 bytes (min. requirement for successful decoding)
+// sub chunks - not restricted amount of bytes
+func DecodeAdtlChunk(data []byte) (*Adtl, error) {
+	header, entries, err := decodeList(data, ADTLID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	byteOrder := binary.LittleEndian
+	a := &Adtl{Header: header}
+
+	for _, entry := range entries {
+		switch {
+		case entry.id == labelID && len(entry.data) >= 4:
+			a.labels = append(a.labels, decodeLabel(entry.data))
+		case entry.id == noteID && len(entry.data) >= 4:
+			a.notes = append(a.notes, decodeLabel(entry.data))
+		case entry.id == labeledTextID && len(entry.data) >= labeledTextSize:
+			a.labeledTexts = append(a.labeledTexts, &LabeledText{
+				CuePointID:   byteOrder.Uint32(entry.data[0:4]),
+				SampleLength: byteOrder.Uint32(entry.data[4:8]),
+				Purpose:      string(entry.data[8:12]),
+				Country:      byteOrder.Uint16(entry.data[12:14]),
+				Language:     byteOrder.Uint16(entry.data[14:16]),
+				Dialect:      byteOrder.Uint16(entry.data[16:18]),
+				CodePage:     byteOrder.Uint16(entry.data[18:20]),
+				Text:         nullTermToString(entry.data[labeledTextSize:]),
+			})
+		default:
+			a.unknown = append(a.unknown, entry)
+		}
+	}
+
+	return a, nil
+}
+
+func encodeLabel(id string, l *Label) *subChunk {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, l.CuePointID)
+	data = append(data, terminate(l.Text, len(l.Text)+1)...)
+
+	return &subChunk{id: id, data: data}
+}
+
+func decodeLabel(data []byte) *Label {
+	return &Label{CuePointID: binary.LittleEndian.Uint32(data[0:4]), Text: nullTermToString(data[4:])}
+}
+
+func findLabel(labels []*Label, cuePointID uint32) *Label {
+	for _, l := range labels {
+		if l.CuePointID == cuePointID {
+			return l
+		}
+	}
+
+	return nil
+}
+
+func readAdtl(reader io.ReaderAt, container *Container) (*Adtl, error) {
+	header, err := findList(reader, container, ADTLID)
+
+	if err != nil || header == nil {
+		return nil, err
+	}
+
+	data, err := ReadChunk(reader, header)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeAdtlChunk(data)
+}
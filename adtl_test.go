@@ -0,0 +1,49 @@
+package chunk
+
+import "testing"
+
+func TestEncodeAdtlChunk(t *testing.T) {
+	chunk := EncodeAdtlChunk([]*Label{{CuePointID: 1, Text: "Intro"}}, []*Label{{CuePointID: 1, Text: "Note"}},
+		[]*LabeledText{{CuePointID: 2, SampleLength: 1000, Purpose: "rgn ", Text: "Verse"}})
+
+	assertEqual(t, chunk.ID(), LISTID, "ID")
+	// list type, labl with padding, note with padding, ltxt
+	assertEqual(t, chunk.Size(), uint32(4+8+10+8+10+8+26), "Size")
+	assertEqual(t, chunk.Label(1).Text, "Intro", "Label")
+	assertEqual(t, chunk.Note(1).Text, "Note", "Note")
+	assertEqual(t, chunk.LabeledText(2).SampleLength, uint32(1000), "LabeledText")
+	assertNil(t, chunk.Label(2), "Label of unknown cue point")
+
+	chunk.SetLabels(nil)
+	chunk.SetNotes(append(chunk.Notes(), &Label{CuePointID: 2, Text: "Second"}))
+	chunk.SetLabeledTexts(nil)
+
+	assertNil(t, chunk.Label(1), "Label after SetLabels")
+	assertEqual(t, chunk.Note(2).Text, "Second", "Note after SetNotes")
+	assertEqual(t, len(chunk.LabeledTexts()), 0, "LabeledTexts length")
+}
+
+func TestDecodeAdtlChunk(t *testing.T) {
+	chunk := EncodeAdtlChunk([]*Label{{CuePointID: 1, Text: "Intro"}}, nil,
+		[]*LabeledText{{CuePointID: 2, SampleLength: 1000, Purpose: "rgn ", Country: 49, Language: 7, Dialect: 1, CodePage: 1252, Text: "Verse"}})
+	data := chunk.Bytes()
+	decoded, err := DecodeAdtlChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(decoded.Labels()), 1, "Labels length")
+	assertEqual(t, decoded.Labels()[0].Text, "Intro", "Label")
+	assertEqual(t, len(decoded.Notes()), 0, "Notes length")
+
+	text := decoded.LabeledText(2)
+
+	assertEqual(t, text.Purpose, "rgn ", "Purpose")
+	assertEqual(t, text.Country, uint16(49), "Country")
+	assertEqual(t, text.Language, uint16(7), "Language")
+	assertEqual(t, text.Dialect, uint16(1), "Dialect")
+	assertEqual(t, text.CodePage, uint16(1252), "CodePage")
+	assertEqual(t, text.Text, "Verse", "Text")
+
+	_, err = DecodeAdtlChunk(EncodeInfoChunk(nil).Bytes())
+
+	assertNotNil(t, err, "err with list type 'INFO'")
+}
@@ -0,0 +1,140 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// AIFFText is AIFF / AIFF-C text chunk 'NAME' (name), 'AUTH' (author), '(c) ' (copyright) or 'ANNO' (annotation).
+type AIFFText struct {
+	*Header
+	text string
+}
+
+// Text returns the text of the chunk.
+func (t *AIFFText) Text() string {
+	return t.text
+}
+
+// SetText sets the text of the chunk.
+func (t *AIFFText) SetText(value string) {
+	t.text = value
+}
+
+// String returns string represensation of chunk.
+func (t *AIFFText) String() string {
+	return fmt.Sprintf("%s: %s", t.ID(), t.text)
+}
+
+// Bytes converts AIFFText to byte array. A new Header with the id of the chunk is created.
+//
+// Header size is set to real data size. A minimum amount of 8 bytes is returned.
+// chunk header - 8 bytes
+// text - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (t *AIFFText) Bytes() []byte {
+	header := EncodeChunkHeader(CreateFourCC(t.ID()), uint32(len(t.text)), binary.BigEndian)
+	bytes := append(header.Bytes(), t.text...)
+
+	return pad(bytes)
+}
+
+// EncodeAIFFTextChunk returns encoded text chunk of provided id, NAMEID, AUTHID, COPYRIGHTID or ANNOID,
+// containing provided text.
+func EncodeAIFFTextChunk(id string, text string) *AIFFText {
+	header := EncodeChunkHeader(CreateFourCC(id), uint32(len(text)), binary.BigEndian)
+
+	return &AIFFText{Header: header, text: text}
+}
+
+// DecodeAIFFTextChunk decodes provided byte array to AIFFText. Trailing null characters are removed.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// text - not restricted amount of bytes
+func DecodeAIFFTextChunk(data []byte) (*AIFFText, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	header := decodeChunkHeader(data[:HeaderSizeBytes], 0, binary.BigEndian)
+	end := int(HeaderSizeBytes + header.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	return &AIFFText{Header: header, text: nullTermToString(data[HeaderSizeBytes:end])}, nil
+}
+
+// AIFFMetadata joins the text chunks, comments and markers of an AIFF / AIFF-C container.
+type AIFFMetadata struct {
+	// Name is the text of chunk 'NAME'.
+	Name string
+	// Author is the text of chunk 'AUTH'.
+	Author string
+	// Copyright is the text of chunk '(c) '.
+	Copyright string
+	// Annotations are the texts of all chunks 'ANNO'.
+	Annotations []string
+	// Comments are the comments of chunk 'COMT', nil if not present.
+	Comments *COMT
+	// Markers are the markers of chunk 'MARK' referenced by comments, nil if not present.
+	Markers *MARK
+}
+
+// ReadAIFFMetadata reads the text chunks, comments and markers of provided container.
+func ReadAIFFMetadata(reader io.ReaderAt, container *Container) (*AIFFMetadata, error) {
+	m := &AIFFMetadata{}
+
+	for _, header := range container.Headers {
+		switch header.ID() {
+		case NAMEID, AUTHID, COPYRIGHTID, ANNOID, COMTID, MARKID:
+		default:
+			continue
+		}
+
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch header.ID() {
+		case COMTID:
+			m.Comments, err = DecodeCOMTChunk(data)
+		case MARKID:
+			m.Markers, err = DecodeMARKChunk(data)
+		default:
+			var text *AIFFText
+			text, err = DecodeAIFFTextChunk(data)
+
+			if err == nil {
+				m.setText(header.ID(), text.Text())
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func (m *AIFFMetadata) setText(id string, text string) {
+	switch id {
+	case NAMEID:
+		m.Name = text
+	case AUTHID:
+		m.Author = text
+	case COPYRIGHTID:
+		m.Copyright = text
+	case ANNOID:
+		m.Annotations = append(m.Annotations, text)
+	}
+}
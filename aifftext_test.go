@@ -0,0 +1,61 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAIFFText(t *testing.T) {
+	chunk := EncodeAIFFTextChunk(NAMEID, "Name")
+
+	assertEqual(t, chunk.ID(), NAMEID, "ID")
+	assertEqual(t, chunk.Size(), uint32(4), "Size")
+
+	chunk.SetText("Odd")
+	data := chunk.Bytes()
+
+	assertEqual(t, len(data), 12, "Bytes length with padding")
+	assertEqual(t, binary.BigEndian.Uint32(data[4:8]), uint32(3), "Size big endian")
+
+	decoded, err := DecodeAIFFTextChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.ID(), NAMEID, "ID")
+	assertEqual(t, decoded.Text(), "Odd", "Text")
+
+	_, err = DecodeAIFFTextChunk(data[:HeaderSizeBytes-1])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestReadAIFFMetadata(t *testing.T) {
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 2, 16, 44100, FourCC{}, ""), 0, make([]byte, 4))
+	chunks := [][]byte{
+		EncodeAIFFTextChunk(NAMEID, "Name").Bytes(),
+		EncodeAIFFTextChunk(AUTHID, "Author").Bytes(),
+		EncodeAIFFTextChunk(COPYRIGHTID, "2021 Copyright").Bytes(),
+		EncodeAIFFTextChunk(ANNOID, "first").Bytes(),
+		EncodeAIFFTextChunk(ANNOID, "second").Bytes(),
+		EncodeMARKChunk([]*AIFFMarker{{ID: 1, Position: 1, Name: "marker"}}).Bytes(),
+		EncodeCOMTChunk([]*AIFFComment{{Timestamp: time.Unix(0, 0), MarkerID: 1, Text: "comment"}}).Bytes(),
+	}
+
+	for _, c := range chunks {
+		aiff = append(aiff, c...)
+	}
+
+	binary.BigEndian.PutUint32(aiff[4:8], uint32(len(aiff)-8))
+	reader := bytes.NewReader(aiff)
+	container, _ := ReadAiff("test", reader)
+	metadata, err := ReadAIFFMetadata(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, metadata.Name, "Name", "Name")
+	assertEqual(t, metadata.Author, "Author", "Author")
+	assertEqual(t, metadata.Copyright, "2021 Copyright", "Copyright")
+	assertEqual(t, strings.Join(metadata.Annotations, ","), "first,second", "Annotations")
+	assertEqual(t, metadata.Comments.Comments()[0].Marker(metadata.Markers).Name, "marker", "comment marker")
+}
@@ -0,0 +1,144 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ApplDecoder decodes the application specific data of chunk 'APPL'.
+type ApplDecoder func(data []byte) (interface{}, error)
+
+var (
+	applDecodersMu sync.RWMutex
+	applDecoders   = map[string]ApplDecoder{
+		ApplXMPSignature: func(data []byte) (interface{}, error) { return DecodeXMP(data) },
+	}
+)
+
+// RegisterApplDecoder registers provided decoder for chunks 'APPL' of provided application signature,
+// replacing a registered decoder. A nil decoder removes the registration.
+func RegisterApplDecoder(signature string, decoder ApplDecoder) {
+	applDecodersMu.Lock()
+	defer applDecodersMu.Unlock()
+
+	if decoder == nil {
+		delete(applDecoders, signature)
+		return
+	}
+
+	applDecoders[signature] = decoder
+}
+
+// Appl is AIFF / AIFF-C application specific chunk 'APPL' identified by an application signature.
+type Appl struct {
+	*Header
+	signature string
+	data      []byte
+}
+
+// Signature is the 4 character application signature (OSType), e.g. 'XMP ' or 'stoc'.
+func (a *Appl) Signature() string {
+	return a.signature
+}
+
+// Data returns the application specific data.
+func (a *Appl) Data() []byte {
+	return a.data
+}
+
+// SetData sets the application specific data.
+func (a *Appl) SetData(value []byte) {
+	a.data = value
+}
+
+// ApplicationName returns the name of the application for signatures 'pdos' and 'stoc', whose data start with
+// the name as pascal string. An empty string is returned for other signatures.
+func (a *Appl) ApplicationName() string {
+	if (a.signature != "pdos" && a.signature != "stoc") || len(a.data) == 0 {
+		return ""
+	}
+
+	name, err := decodePascalString(bytes.NewReader(a.data))
+
+	if err != nil {
+		return ""
+	}
+
+	return name
+}
+
+// Decode decodes the application specific data by the decoder registered for the signature.
+// An error is returned if no decoder is registered.
+func (a *Appl) Decode() (interface{}, error) {
+	applDecodersMu.RLock()
+	decoder := applDecoders[a.signature]
+	applDecodersMu.RUnlock()
+
+	if decoder == nil {
+		msg := fmt.Sprintf("no decoder registered for application signature '%s'", a.signature)
+		return nil, errors.New(msg)
+	}
+
+	return decoder(a.data)
+}
+
+// String returns string represensation of chunk.
+func (a *Appl) String() string {
+	return fmt.Sprintf("Signature: %s\nData: %d", a.signature, len(a.data))
+}
+
+// Bytes converts Appl to byte array. A new Header with id 'APPL' is created.
+//
+// Header size is set to real data size. A minimum amount of 12 bytes is returned.
+// chunk header - 8 bytes
+// application signature - 4 bytes
+// data - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (a *Appl) Bytes() []byte {
+	signature := CreateFourCC(a.signature)
+	data := append(signature[:], a.data...)
+	header := EncodeChunkHeader(CreateFourCC(APPLID), uint32(len(data)), binary.BigEndian)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeApplChunk returns encoded chunk 'APPL' of provided application signature containing provided data.
+func EncodeApplChunk(signature string, data []byte) *Appl {
+	header := EncodeChunkHeader(CreateFourCC(APPLID), uint32(4+len(data)), binary.BigEndian)
+
+	return &Appl{Header: header, signature: signature, data: data}
+}
+
+// DecodeApplChunk decodes provided byte array to Appl.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// application signature - 4 bytes (min. requirement for successful decoding)
+// data - not restricted amount of bytes
+func DecodeApplChunk(data []byte) (*Appl, error) {
+	if len(data) < int(HeaderSizeBytes)+4 {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", int(HeaderSizeBytes)+4)
+		return nil, errors.New(msg)
+	}
+
+	header := decodeChunkHeader(data[:HeaderSizeBytes], 0, binary.BigEndian)
+	end := int(HeaderSizeBytes + header.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	if end < int(HeaderSizeBytes)+4 {
+		end = int(HeaderSizeBytes) + 4
+	}
+
+	a := &Appl{Header: header, signature: string(data[HeaderSizeBytes : HeaderSizeBytes+4])}
+	a.data = append([]byte{}, data[HeaderSizeBytes+4:end]...)
+
+	return a, nil
+}
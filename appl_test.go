@@ -0,0 +1,58 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestAppl(t *testing.T) {
+	chunk := EncodeApplChunk("test", []byte{1, 2, 3})
+
+	assertEqual(t, chunk.ID(), APPLID, "ID")
+	assertEqual(t, chunk.Size(), uint32(7), "Size")
+	assertEqual(t, chunk.Signature(), "test", "Signature")
+
+	data := chunk.Bytes()
+
+	assertEqual(t, len(data), 16, "Bytes length with padding")
+	assertEqual(t, binary.BigEndian.Uint32(data[4:8]), uint32(7), "Size big endian")
+
+	decoded, err := DecodeApplChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Signature(), "test", "Signature")
+	assertEqual(t, bytes.Equal(decoded.Data(), []byte{1, 2, 3}), true, "Data")
+
+	_, err = DecodeApplChunk(data[:11])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestApplApplicationName(t *testing.T) {
+	data := append(encodePascalString("Editor"), 1, 2)
+	chunk := EncodeApplChunk("stoc", data)
+
+	assertEqual(t, chunk.ApplicationName(), "Editor", "ApplicationName")
+
+	chunk = EncodeApplChunk("test", data)
+
+	assertEqual(t, chunk.ApplicationName(), "", "ApplicationName of other signature")
+}
+
+func TestApplDecode(t *testing.T) {
+	chunk := EncodeApplChunk("tst1", []byte("value"))
+	_, err := chunk.Decode()
+
+	assertNotNil(t, err, "err without decoder")
+
+	RegisterApplDecoder("tst1", func(data []byte) (interface{}, error) {
+		return string(data), nil
+	})
+	defer RegisterApplDecoder("tst1", nil)
+
+	value, err := chunk.Decode()
+
+	assertNil(t, err, "err")
+	assertEqual(t, value, "value", "decoded value")
+}
@@ -0,0 +1,306 @@
+package chunk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	// XMLUncompressed is the format info of chunk 'bxml' containing uncompressed XML.
+	XMLUncompressed uint16 = 0
+	// XMLGzip is the format info of chunk 'bxml' containing gzip compressed XML.
+	XMLGzip uint16 = 1
+)
+
+// AXML is BW64 chunk 'axml' containing an XML document, usually the Audio Definition Model (ITU-R BS.2088).
+// The XML is preserved as read, the ADM is decoded and encoded on demand.
+type AXML struct {
+	*Header
+	data []byte
+}
+
+// XML returns the XML document.
+func (a *AXML) XML() []byte {
+	return a.data
+}
+
+// SetXML sets the XML document.
+func (a *AXML) SetXML(data []byte) {
+	a.data = data
+}
+
+// ADM decodes the XML document to ADM.
+func (a *AXML) ADM() (*ADM, error) {
+	return DecodeADM(a.data)
+}
+
+// SetADM sets the XML document to encoded provided ADM.
+func (a *AXML) SetADM(adm *ADM) error {
+	data, err := EncodeADM(adm)
+
+	if err != nil {
+		return err
+	}
+
+	a.data = data
+
+	return nil
+}
+
+// String returns string represensation of chunk.
+func (a *AXML) String() string {
+	return string(a.data)
+}
+
+// Bytes converts AXML to byte array. A new Header with id 'axml' is created.
+//
+// Header size is set to real data size. A minimum amount of 8 bytes is returned.
+// chunk header - 8 bytes
+// XML - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (a *AXML) Bytes() []byte {
+	header := EncodeChunkHeader(CreateFourCC(AXMLID), uint32(len(a.data)), binary.LittleEndian)
+	bytes := append(header.Bytes(), a.data...)
+
+	return pad(bytes)
+}
+
+// EncodeAXMLChunk returns encoded chunk 'axml' containing provided XML document.
+func EncodeAXMLChunk(data []byte) *AXML {
+	header := EncodeChunkHeader(CreateFourCC(AXMLID), uint32(len(data)), binary.LittleEndian)
+
+	return &AXML{Header: header, data: data}
+}
+
+// DecodeAXMLChunk decodes provided byte array to AXML.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// XML - not restricted amount of bytes
+func DecodeAXMLChunk(data []byte) (*AXML, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	header := decodeChunkHeader(data[:HeaderSizeBytes], 0, binary.LittleEndian)
+	end := int(HeaderSizeBytes + header.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	return &AXML{Header: header, data: append([]byte{}, data[HeaderSizeBytes:end]...)}, nil
+}
+
+// BXML is BW64 chunk 'bxml' containing a compressed XML document. The payload is preserved as read.
+//
+// Chunk 'sxml' (serial ADM) has a section based layout and is not supported, it is preserved as unknown chunk.
+type BXML struct {
+	*Header
+	formatInfo uint16
+	data       []byte
+}
+
+// FormatInfo is XMLUncompressed or XMLGzip.
+func (b *BXML) FormatInfo() uint16 {
+	return b.formatInfo
+}
+
+// Data returns the payload as stored in the chunk.
+func (b *BXML) Data() []byte {
+	return b.data
+}
+
+// XML returns the uncompressed payload.
+func (b *BXML) XML() ([]byte, error) {
+	switch b.formatInfo {
+	case XMLUncompressed:
+		return b.data, nil
+	case XMLGzip:
+		r, err := gzip.NewReader(bytes.NewReader(b.data))
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer r.Close()
+
+		return ioutil.ReadAll(r)
+	default:
+		msg := fmt.Sprintf("unsupported format info %d", b.formatInfo)
+		return nil, errors.New(msg)
+	}
+}
+
+// ADM decodes the uncompressed XML document to ADM.
+func (b *BXML) ADM() (*ADM, error) {
+	data, err := b.XML()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeADM(data)
+}
+
+// String returns string represensation of chunk.
+func (b *BXML) String() string {
+	return fmt.Sprintf("Format info: %d\nData: %d", b.formatInfo, len(b.data))
+}
+
+// Bytes converts BXML to byte array. A new Header with the id of the chunk is created.
+//
+// Header size is set to real data size. A minimum amount of 10 bytes is returned.
+// chunk header - 8 bytes
+// format info - 2 bytes
+// payload - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (b *BXML) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	data := make([]byte, 2)
+	byteOrder.PutUint16(data, b.formatInfo)
+	data = append(data, b.data...)
+	header := EncodeChunkHeader(CreateFourCC(b.ID()), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeBXMLChunk returns encoded chunk 'bxml' containing provided XML compressed according to provided format info.
+func EncodeBXMLChunk(formatInfo uint16, xml []byte) (*BXML, error) {
+	data := xml
+
+	switch formatInfo {
+	case XMLUncompressed:
+	case XMLGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write(xml)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = w.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		data = buf.Bytes()
+	default:
+		msg := fmt.Sprintf("unsupported format info %d", formatInfo)
+		return nil, errors.New(msg)
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(BXMLID), uint32(2+len(data)), binary.LittleEndian)
+
+	return &BXML{Header: header, formatInfo: formatInfo, data: data}, nil
+}
+
+// DecodeBXMLChunk decodes provided byte array to BXML.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// format info - 2 bytes
+// payload - not restricted amount of bytes
+func DecodeBXMLChunk(data []byte) (*BXML, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	b := &BXML{}
+	byteOrder := binary.LittleEndian
+	b.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	err := binary.Read(buf, byteOrder, &b.formatInfo)
+
+	if err != nil {
+		return b, err
+	}
+
+	size := int(b.Size()) - 2
+
+	if size < 0 || size > buf.Len() {
+		size = buf.Len()
+	}
+
+	b.data = make([]byte, size)
+	err = binary.Read(buf, byteOrder, &b.data)
+
+	if err != nil {
+		return b, err
+	}
+
+	return b, nil
+}
+
+// ReadADM returns the ADM of chunk 'axml' or, if not present, of chunk 'bxml' and chunk 'chna'
+// of provided container. Nil is returned for chunks not present.
+func ReadADM(reader io.ReaderAt, container *Container) (*ADM, *Chna, error) {
+	var adm *ADM
+	var chna *Chna
+
+	if headers := container.FindHeaders(AXMLID); len(headers) > 0 {
+		data, err := ReadChunk(reader, headers[0])
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		a, err := DecodeAXMLChunk(data)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adm, err = a.ADM()
+
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if headers := container.FindHeaders(BXMLID); len(headers) > 0 {
+		data, err := ReadChunk(reader, headers[0])
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		b, err := DecodeBXMLChunk(data)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adm, err = b.ADM()
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if headers := container.FindHeaders(CHNAID); len(headers) > 0 {
+		data, err := ReadChunk(reader, headers[0])
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		chna, err = DecodeChnaChunk(data)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return adm, chna, nil
+}
@@ -0,0 +1,94 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestAXML(t *testing.T) {
+	chunk := EncodeAXMLChunk([]byte(testADM))
+
+	assertEqual(t, chunk.ID(), AXMLID, "ID")
+	assertEqual(t, chunk.Size(), uint32(len(testADM)), "Size")
+
+	decoded, err := DecodeAXMLChunk(chunk.Bytes())
+
+	assertNil(t, err, "err")
+	assertEqual(t, string(decoded.XML()), testADM, "XML")
+
+	adm, err := decoded.ADM()
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(adm.TrackUIDs), 2, "TrackUIDs length")
+
+	adm.TrackUIDs = adm.TrackUIDs[:1]
+	err = decoded.SetADM(adm)
+
+	assertNil(t, err, "err")
+
+	adm, _ = decoded.ADM()
+
+	assertEqual(t, len(adm.TrackUIDs), 1, "TrackUIDs length after SetADM")
+
+	_, err = DecodeAXMLChunk(make([]byte, HeaderSizeBytes-1))
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestBXML(t *testing.T) {
+	for _, formatInfo := range []uint16{XMLUncompressed, XMLGzip} {
+		chunk, err := EncodeBXMLChunk(formatInfo, []byte(testADM))
+
+		assertNil(t, err, "err")
+		assertEqual(t, chunk.FormatInfo(), formatInfo, "FormatInfo")
+
+		decoded, err := DecodeBXMLChunk(chunk.Bytes())
+
+		assertNil(t, err, "err")
+		assertEqual(t, bytes.Equal(decoded.Data(), chunk.Data()), true, "Data")
+
+		xml, err := decoded.XML()
+
+		assertNil(t, err, "err")
+		assertEqual(t, string(xml), testADM, "XML")
+
+		adm, err := decoded.ADM()
+
+		assertNil(t, err, "err")
+		assertEqual(t, len(adm.Objects), 1, "Objects length")
+	}
+
+	chunk, _ := EncodeBXMLChunk(XMLGzip, []byte(testADM))
+
+	assertEqual(t, chunk.ID(), BXMLID, "ID")
+	assertEqual(t, string(chunk.Bytes()[:4]), BXMLID, "Bytes ID")
+
+	_, err := EncodeBXMLChunk(2, []byte(testADM))
+
+	assertNotNil(t, err, "err with unsupported format info")
+}
+
+func TestReadADM(t *testing.T) {
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), make([]byte, 4))
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	adm, chna, err := ReadADM(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, adm, (*ADM)(nil), "ADM not present")
+	assertEqual(t, chna, (*Chna)(nil), "Chna not present")
+
+	bxml, _ := EncodeBXMLChunk(XMLGzip, []byte(testADM))
+	chunk := EncodeChnaChunk([]*ChnaAudioID{{TrackIndex: 1, UID: "ATU_00000001"}})
+	riff = append(riff, bxml.Bytes()...)
+	riff = append(riff, chunk.Bytes()...)
+	binary.LittleEndian.PutUint32(riff[4:8], uint32(len(riff)-8))
+	reader = bytes.NewReader(riff)
+	container, _ = ReadRiff("test", reader)
+	adm, chna, err = ReadADM(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(adm.Objects), 1, "Objects length")
+	assertEqual(t, chna.NumTracks(), 1, "NumTracks")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 )
 
 // Bext is Broadcast Wave Format (BWF) bext chunk 'bext' describing extended information.
@@ -172,21 +173,21 @@ func (b *Bext) SetOriginationTime(value string) {
 	copy(b.originationTime[:], value)
 }
 
-// TimeReference is the timecode.
+// TimeReference is the timecode as number of samples since midnight.
 // (since version 0)
 func (b *Bext) TimeReference() uint64 {
 	var value uint64
-	value += uint64(b.timeReferenceLow) << 32
-	value += uint64(b.timeReferenceHigh)
+	value += uint64(b.timeReferenceHigh) << 32
+	value += uint64(b.timeReferenceLow)
 
 	return value
 }
 
-// SetTimeReference sets the timecode.
+// SetTimeReference sets the timecode as number of samples since midnight.
 // (since version 0)
 func (b *Bext) SetTimeReference(value uint64) {
-	b.timeReferenceLow = uint32(value >> 32)
-	b.timeReferenceHigh = uint32(value)
+	b.timeReferenceLow = uint32(value)
+	b.timeReferenceHigh = uint32(value >> 32)
 }
 
 // Version is the version of Broadcast Wave Format (BWF).
@@ -417,3 +418,20 @@ func DecodeBextChunk(data []byte) (*Bext, error) {
 
 	return b, nil
 }
+
+// readBext reads and decodes the first 'bext' chunk of provided container. Nil is returned if not present.
+func readBext(reader io.ReaderAt, container *Container) (*Bext, error) {
+	headers := container.FindHeaders(BEXTID)
+
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	data, err := ReadChunk(reader, headers[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeBextChunk(data)
+}
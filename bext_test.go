@@ -85,6 +85,11 @@ func TestBext(t *testing.T) {
 	bext, err := DecodeBextChunk(bytes)
 	assertEqual(t, err, nil, "err")
 	assertEqual(t, bext.CodingHistory(), codingHistory, "codingHistory")
+
+	chunk.SetTimeReference(1<<32 + 2)
+	bytes = chunk.Bytes()
+	assertEqual(t, binary.LittleEndian.Uint32(bytes[HeaderSizeBytes+338:HeaderSizeBytes+342]), uint32(2), "timeReferenceLow")
+	assertEqual(t, binary.LittleEndian.Uint32(bytes[HeaderSizeBytes+342:HeaderSizeBytes+346]), uint32(1), "timeReferenceHigh")
 }
 
 func TestBextBytes(t *testing.T) {
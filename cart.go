@@ -0,0 +1,434 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// CartVersion is the version of the cart chunk data structure written by EncodeCartChunk.
+	CartVersion = "0101"
+	// cartSize is the byte size of the cart chunk data without tag text.
+	cartSize = 2048
+	// numPostTimers is the number of post timers of a cart chunk.
+	numPostTimers = 8
+)
+
+// PostTimer marks a position in the sound data by a usage, e.g. 'SEC1' (segue start) or 'EOD ' (end of data).
+type PostTimer struct {
+	// Usage is a 4 character code, an empty usage marks an unused timer.
+	Usage string
+	// Value is the sample frame of the timer.
+	Value uint32
+}
+
+// String returns string represensation of post timer.
+func (p PostTimer) String() string {
+	return fmt.Sprintf("Usage: %s Value: %d", p.Usage, p.Value)
+}
+
+// Cart is AES46 cart chunk 'cart' describing radio traffic data of a cut.
+type Cart struct {
+	*Header
+	version            [4]byte
+	title              [64]byte
+	artist             [64]byte
+	cutID              [64]byte
+	clientID           [64]byte
+	category           [64]byte
+	classification     [64]byte
+	outCue             [64]byte
+	startDate          [10]byte
+	startTime          [8]byte
+	endDate            [10]byte
+	endTime            [8]byte
+	producerAppID      [64]byte
+	producerAppVersion [64]byte
+	userDef            [64]byte
+	levelReference     int32
+	postTimers         [numPostTimers]postTimer
+	reserved           [276]byte
+	url                [1024]byte
+	tagText            []byte
+}
+
+// postTimer is the binary representation of PostTimer.
+type postTimer struct {
+	usage [4]byte
+	value uint32
+}
+
+// Version is the version of the data structure, e.g. '0101' for version 1.01.
+// Max. 4 characters.
+func (c *Cart) Version() string {
+	return nullTermToString(c.version[:])
+}
+
+// SetVersion sets the version of the data structure, e.g. '0101' for version 1.01.
+// Max. 4 characters.
+func (c *Cart) SetVersion(value string) {
+	c.version = [4]byte{}
+	copy(c.version[:], terminate(value, len(c.version)))
+}
+
+// Title is the title of the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) Title() string {
+	return nullTermToString(c.title[:])
+}
+
+// SetTitle sets the title of the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetTitle(value string) {
+	c.title = [64]byte{}
+	copy(c.title[:], terminate(value, len(c.title)))
+}
+
+// Artist is the artist or creator of the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) Artist() string {
+	return nullTermToString(c.artist[:])
+}
+
+// SetArtist sets the artist or creator of the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetArtist(value string) {
+	c.artist = [64]byte{}
+	copy(c.artist[:], terminate(value, len(c.artist)))
+}
+
+// CutID is the cut number identifying the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) CutID() string {
+	return nullTermToString(c.cutID[:])
+}
+
+// SetCutID sets the cut number identifying the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetCutID(value string) {
+	c.cutID = [64]byte{}
+	copy(c.cutID[:], terminate(value, len(c.cutID)))
+}
+
+// ClientID identifies the client.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) ClientID() string {
+	return nullTermToString(c.clientID[:])
+}
+
+// SetClientID sets the client identification.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetClientID(value string) {
+	c.clientID = [64]byte{}
+	copy(c.clientID[:], terminate(value, len(c.clientID)))
+}
+
+// Category is the category of the cut, e.g. 'NEWS'.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) Category() string {
+	return nullTermToString(c.category[:])
+}
+
+// SetCategory sets the category of the cut, e.g. 'NEWS'.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetCategory(value string) {
+	c.category = [64]byte{}
+	copy(c.category[:], terminate(value, len(c.category)))
+}
+
+// Classification is a user defined classification of the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) Classification() string {
+	return nullTermToString(c.classification[:])
+}
+
+// SetClassification
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetClassification(value string) {
+	c.classification = [64]byte{}
+	copy(c.classification[:], terminate(value, len(c.classification)))
+}
+
+// OutCue is the text of the closing words of the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) OutCue() string {
+	return nullTermToString(c.outCue[:])
+}
+
+// SetOutCue sets the text of the closing words of the cut.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetOutCue(value string) {
+	c.outCue = [64]byte{}
+	copy(c.outCue[:], terminate(value, len(c.outCue)))
+}
+
+// StartDate is the date the cut becomes valid: yyyy-mm-dd.
+// Max. 10 characters.
+func (c *Cart) StartDate() string {
+	return nullTermToString(c.startDate[:])
+}
+
+// SetStartDate sets the date the cut becomes valid: yyyy-mm-dd.
+// Max. 10 characters.
+func (c *Cart) SetStartDate(value string) {
+	c.startDate = [10]byte{}
+	copy(c.startDate[:], terminate(value, len(c.startDate)))
+}
+
+// StartTime is the time the cut becomes valid: hh:mm:ss.
+// Max. 8 characters.
+func (c *Cart) StartTime() string {
+	return nullTermToString(c.startTime[:])
+}
+
+// SetStartTime sets the time the cut becomes valid: hh:mm:ss.
+// Max. 8 characters.
+func (c *Cart) SetStartTime(value string) {
+	c.startTime = [8]byte{}
+	copy(c.startTime[:], terminate(value, len(c.startTime)))
+}
+
+// EndDate is the date the cut expires: yyyy-mm-dd.
+// Max. 10 characters.
+func (c *Cart) EndDate() string {
+	return nullTermToString(c.endDate[:])
+}
+
+// SetEndDate sets the date the cut expires: yyyy-mm-dd.
+// Max. 10 characters.
+func (c *Cart) SetEndDate(value string) {
+	c.endDate = [10]byte{}
+	copy(c.endDate[:], terminate(value, len(c.endDate)))
+}
+
+// EndTime is the time the cut expires: hh:mm:ss.
+// Max. 8 characters.
+func (c *Cart) EndTime() string {
+	return nullTermToString(c.endTime[:])
+}
+
+// SetEndTime sets the time the cut expires: hh:mm:ss.
+// Max. 8 characters.
+func (c *Cart) SetEndTime(value string) {
+	c.endTime = [8]byte{}
+	copy(c.endTime[:], terminate(value, len(c.endTime)))
+}
+
+// ProducerAppID is the name of the application which created the cart chunk.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) ProducerAppID() string {
+	return nullTermToString(c.producerAppID[:])
+}
+
+// SetProducerAppID sets the name of the application which created the cart chunk.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetProducerAppID(value string) {
+	c.producerAppID = [64]byte{}
+	copy(c.producerAppID[:], terminate(value, len(c.producerAppID)))
+}
+
+// ProducerAppVersion is the version of the producer application.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) ProducerAppVersion() string {
+	return nullTermToString(c.producerAppVersion[:])
+}
+
+// SetProducerAppVersion sets the version of the producer application.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetProducerAppVersion(value string) {
+	c.producerAppVersion = [64]byte{}
+	copy(c.producerAppVersion[:], terminate(value, len(c.producerAppVersion)))
+}
+
+// UserDef is user defined text.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) UserDef() string {
+	return nullTermToString(c.userDef[:])
+}
+
+// SetUserDef sets user defined text.
+// Max. 64 characters and null terminated if shorter.
+func (c *Cart) SetUserDef(value string) {
+	c.userDef = [64]byte{}
+	copy(c.userDef[:], terminate(value, len(c.userDef)))
+}
+
+// LevelReference is the sample value of 0 dB reference level.
+func (c *Cart) LevelReference() int32 {
+	return c.levelReference
+}
+
+// SetLevelReference
+func (c *Cart) SetLevelReference(value int32) {
+	c.levelReference = value
+}
+
+// PostTimers returns the eight post timers.
+func (c *Cart) PostTimers() [numPostTimers]PostTimer {
+	var timers [numPostTimers]PostTimer
+
+	for i, t := range c.postTimers {
+		timers[i] = PostTimer{Usage: nullTermToString(t.usage[:]), Value: t.value}
+	}
+
+	return timers
+}
+
+// SetPostTimers sets the eight post timers. Usage is truncated to 4 characters.
+func (c *Cart) SetPostTimers(timers [numPostTimers]PostTimer) {
+	for i, t := range timers {
+		c.postTimers[i] = postTimer{value: t.Value}
+		copy(c.postTimers[i].usage[:], t.Usage)
+	}
+}
+
+// PostTimer returns the first post timer of provided usage and true, false if not found.
+func (c *Cart) PostTimer(usage string) (PostTimer, bool) {
+	for _, t := range c.PostTimers() {
+		if t.Usage == usage {
+			return t, true
+		}
+	}
+
+	return PostTimer{}, false
+}
+
+// URL is an uniform resource locator referencing further information about the cut.
+// Max. 1024 characters and null terminated if shorter.
+func (c *Cart) URL() string {
+	return nullTermToString(c.url[:])
+}
+
+// SetURL
+// Max. 1024 characters and null terminated if shorter.
+func (c *Cart) SetURL(value string) {
+	c.url = [1024]byte{}
+	copy(c.url[:], terminate(value, len(c.url)))
+}
+
+// TagText is free text, lines are terminated by CR/LF.
+func (c *Cart) TagText() string {
+	return nullTermToString(c.tagText)
+}
+
+// SetTagText
+func (c *Cart) SetTagText(value string) {
+	c.tagText = []byte(value)
+}
+
+// String returns string represensation of chunk.
+func (c *Cart) String() string {
+	timers := c.PostTimers()
+	lines := make([]string, len(timers))
+
+	for i, t := range timers {
+		lines[i] = t.String()
+	}
+
+	return fmt.Sprintf("Version: %s\nTitle: %s\nArtist: %s\nCut ID: %s\nClient ID: %s\nCategory: %s\nClassification: %s\nOut Cue: %s\nStart Date: %s\nStart Time: %s\nEnd Date: %s\nEnd Time: %s\nProducer App ID: %s\nProducer App Version: %s\nUser Def: %s\nLevel Reference: %d\nPost Timers:\n%s\nURL: %s\nTag Text: %s",
+		c.Version(), c.Title(), c.Artist(), c.CutID(), c.ClientID(), c.Category(), c.Classification(), c.OutCue(), c.StartDate(), c.StartTime(), c.EndDate(), c.EndTime(),
+		c.ProducerAppID(), c.ProducerAppVersion(), c.UserDef(), c.LevelReference(), strings.Join(lines, "\n"), c.URL(), c.TagText())
+}
+
+// Bytes converts Cart to byte array. A new Header with id 'cart' is created.
+//
+// Header size is set to real data size. A minimum amount of 2056 bytes is returned.
+// chunk header - 8 bytes
+// version - 4 bytes
+// title, artist, cut id, client id, category, classification, out cue - 64 bytes each
+// start date - 10 bytes
+// start time - 8 bytes
+// end date - 10 bytes
+// end time - 8 bytes
+// producer app id, producer app version, user def - 64 bytes each
+// level reference - 4 bytes
+// post timers - 8 bytes each: usage, value
+// reserved - 276 bytes
+// url - 1024 bytes
+// tag text - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (c *Cart) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	buf := new(bytes.Buffer)
+	fields := []interface{}{&c.version, &c.title, &c.artist, &c.cutID, &c.clientID, &c.category, &c.classification, &c.outCue,
+		&c.startDate, &c.startTime, &c.endDate, &c.endTime, &c.producerAppID, &c.producerAppVersion, &c.userDef, &c.levelReference}
+
+	for _, f := range fields {
+		binary.Write(buf, byteOrder, f)
+	}
+
+	for _, t := range c.postTimers {
+		buf.Write(t.usage[:])
+		binary.Write(buf, byteOrder, t.value)
+	}
+
+	buf.Write(c.reserved[:])
+	buf.Write(c.url[:])
+	buf.Write(c.tagText)
+	data := buf.Bytes()
+	header := EncodeChunkHeader(CreateFourCC(CARTID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeCartChunk returns an empty chunk 'cart' of version CartVersion, fields are set by setters.
+func EncodeCartChunk() *Cart {
+	c := &Cart{Header: EncodeChunkHeader(CreateFourCC(CARTID), cartSize, binary.LittleEndian)}
+	c.SetVersion(CartVersion)
+
+	return c
+}
+
+// DecodeCartChunk decodes provided byte array to Cart.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// data - 2048 bytes
+// tag text - not restricted amount of bytes
+func DecodeCartChunk(data []byte) (*Cart, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	c := &Cart{}
+	byteOrder := binary.LittleEndian
+	c.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	fields := []interface{}{&c.version, &c.title, &c.artist, &c.cutID, &c.clientID, &c.category, &c.classification, &c.outCue,
+		&c.startDate, &c.startTime, &c.endDate, &c.endTime, &c.producerAppID, &c.producerAppVersion, &c.userDef, &c.levelReference}
+
+	for i := range c.postTimers {
+		fields = append(fields, &c.postTimers[i].usage, &c.postTimers[i].value)
+	}
+
+	fields = append(fields, &c.reserved, &c.url)
+
+	for _, f := range fields {
+		err := binary.Read(buf, byteOrder, f)
+
+		if err != nil {
+			return c, err
+		}
+	}
+
+	size := int(c.Size()) - cartSize
+
+	if size < 0 || size > buf.Len() {
+		size = buf.Len()
+	}
+
+	c.tagText = make([]byte, size)
+	err := binary.Read(buf, byteOrder, &c.tagText)
+
+	if err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,85 @@
+package chunk
+
+import "testing"
+
+func TestCart(t *testing.T) {
+	chunk := EncodeCartChunk()
+
+	assertEqual(t, chunk.ID(), CARTID, "ID")
+	assertEqual(t, chunk.Size(), uint32(2048), "Size")
+	assertEqual(t, chunk.Version(), CartVersion, "Version")
+
+	title := createTestString(64)
+	chunk.SetTitle(title)
+	assertEqual(t, chunk.Title(), title, "Title")
+
+	chunk.SetTitle("Morning News")
+	assertEqual(t, chunk.Title(), "Morning News", "Title")
+
+	chunk.SetStartDate("2021-01-01")
+	assertEqual(t, chunk.StartDate(), "2021-01-01", "StartDate")
+
+	chunk.SetStartTime("00:00:00")
+	assertEqual(t, chunk.StartTime(), "00:00:00", "StartTime")
+
+	url := createTestString(1024)
+	chunk.SetURL(url)
+	assertEqual(t, chunk.URL(), url, "URL")
+
+	timers := [8]PostTimer{{Usage: "SEC1", Value: 44100}, {Usage: "EOD", Value: 88200}}
+	chunk.SetPostTimers(timers)
+	assertEqual(t, chunk.PostTimers(), timers, "PostTimers")
+
+	timer, ok := chunk.PostTimer("EOD")
+	assertEqual(t, ok, true, "PostTimer found")
+	assertEqual(t, timer.Value, uint32(88200), "PostTimer value")
+
+	_, ok = chunk.PostTimer("SEG1")
+	assertEqual(t, ok, false, "PostTimer not found")
+
+	chunk.SetTagText("<tag>\r\n")
+	assertEqual(t, len(chunk.Bytes()), 8+2048+8, "Bytes length with padding")
+}
+
+func TestDecodeCartChunk(t *testing.T) {
+	chunk := EncodeCartChunk()
+	chunk.SetArtist("Artist")
+	chunk.SetCutID("12345")
+	chunk.SetClientID("Client")
+	chunk.SetCategory("NEWS")
+	chunk.SetClassification("Class")
+	chunk.SetOutCue("and that's the news")
+	chunk.SetEndDate("2021-12-31")
+	chunk.SetEndTime("23:59:59")
+	chunk.SetProducerAppID("chunk")
+	chunk.SetProducerAppVersion("1.0")
+	chunk.SetUserDef("user")
+	chunk.SetLevelReference(32768)
+	chunk.SetPostTimers([8]PostTimer{{Usage: "INT1", Value: 1000}})
+	chunk.SetTagText("tag")
+	data := chunk.Bytes()
+
+	decoded, err := DecodeCartChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Size(), uint32(2051), "Size")
+	assertEqual(t, decoded.Version(), CartVersion, "Version")
+	assertEqual(t, decoded.Artist(), "Artist", "Artist")
+	assertEqual(t, decoded.CutID(), "12345", "CutID")
+	assertEqual(t, decoded.ClientID(), "Client", "ClientID")
+	assertEqual(t, decoded.Category(), "NEWS", "Category")
+	assertEqual(t, decoded.Classification(), "Class", "Classification")
+	assertEqual(t, decoded.OutCue(), "and that's the news", "OutCue")
+	assertEqual(t, decoded.EndDate(), "2021-12-31", "EndDate")
+	assertEqual(t, decoded.EndTime(), "23:59:59", "EndTime")
+	assertEqual(t, decoded.ProducerAppID(), "chunk", "ProducerAppID")
+	assertEqual(t, decoded.ProducerAppVersion(), "1.0", "ProducerAppVersion")
+	assertEqual(t, decoded.UserDef(), "user", "UserDef")
+	assertEqual(t, decoded.LevelReference(), int32(32768), "LevelReference")
+	assertEqual(t, decoded.PostTimers()[0], PostTimer{Usage: "INT1", Value: 1000}, "PostTimers")
+	assertEqual(t, decoded.TagText(), "tag", "TagText")
+
+	_, err = DecodeCartChunk(data[:HeaderSizeBytes+100])
+
+	assertNotNil(t, err, "err with short data")
+}
@@ -0,0 +1,193 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// chnaAudioIDSize is the byte size of an audio id of chunk 'chna'.
+const chnaAudioIDSize = 40
+
+// ChnaAudioID maps a track of the sound data to ADM ids of chunk 'axml' (ITU-R BS.2088).
+type ChnaAudioID struct {
+	// TrackIndex is the 1 based index of the track in the sound data, 0 for an unused entry.
+	TrackIndex uint16
+	// UID is the audioTrackUID, e.g. 'ATU_00000001'.
+	UID string
+	// TrackFormatRef is the audioTrackFormatID, e.g. 'AT_00010001_01'.
+	TrackFormatRef string
+	// PackFormatRef is the audioPackFormatID, e.g. 'AP_00010002'.
+	PackFormatRef string
+}
+
+// String returns string represensation of audio id.
+func (a *ChnaAudioID) String() string {
+	return fmt.Sprintf("Track index: %d UID: %s Track format ref: %s Pack format ref: %s", a.TrackIndex, a.UID, a.TrackFormatRef, a.PackFormatRef)
+}
+
+// Chna is BW64 chunk 'chna' mapping the tracks of the sound data to ADM ids (ITU-R BS.2088).
+type Chna struct {
+	*Header
+	audioIDs []*ChnaAudioID
+}
+
+// AudioIDs returns the audio ids.
+func (c *Chna) AudioIDs() []*ChnaAudioID {
+	return c.audioIDs
+}
+
+// SetAudioIDs sets the audio ids.
+func (c *Chna) SetAudioIDs(audioIDs []*ChnaAudioID) {
+	c.audioIDs = audioIDs
+}
+
+// NumTracks is the number of distinct tracks referenced by the audio ids.
+func (c *Chna) NumTracks() int {
+	tracks := make(map[uint16]bool)
+
+	for _, a := range c.audioIDs {
+		if a.TrackIndex > 0 {
+			tracks[a.TrackIndex] = true
+		}
+	}
+
+	return len(tracks)
+}
+
+// Track returns the audio ids of provided 1 based track index.
+func (c *Chna) Track(trackIndex int) []*ChnaAudioID {
+	var audioIDs []*ChnaAudioID
+
+	for _, a := range c.audioIDs {
+		if int(a.TrackIndex) == trackIndex {
+			audioIDs = append(audioIDs, a)
+		}
+	}
+
+	return audioIDs
+}
+
+// numUIDs is the number of used audio ids.
+func (c *Chna) numUIDs() int {
+	n := 0
+
+	for _, a := range c.audioIDs {
+		if a.TrackIndex > 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// String returns string represensation of chunk.
+func (c *Chna) String() string {
+	lines := make([]string, len(c.audioIDs))
+
+	for i, a := range c.audioIDs {
+		lines[i] = a.String()
+	}
+
+	return fmt.Sprintf("Tracks: %d\nUIDs: %d\n%s", c.NumTracks(), len(c.audioIDs), strings.Join(lines, "\n"))
+}
+
+// Bytes converts Chna to byte array. A new Header with id 'chna' is created.
+//
+// Header size is set to real data size. A minimum amount of 12 bytes is returned.
+// chunk header - 8 bytes
+// number of tracks - 2 bytes
+// number of UIDs - 2 bytes
+// audio ids - 40 bytes each: track index (2 bytes), UID (12 bytes), track format ref (14 bytes),
+// pack format ref (11 bytes), padding (1 byte)
+//
+// Unused audio ids with track index 0 are written as reserved entries and not counted in number of UIDs.
+func (c *Chna) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	data := make([]byte, 4+chnaAudioIDSize*len(c.audioIDs))
+	byteOrder.PutUint16(data[0:2], uint16(c.NumTracks()))
+	byteOrder.PutUint16(data[2:4], uint16(c.numUIDs()))
+
+	for i, a := range c.audioIDs {
+		pos := 4 + i*chnaAudioIDSize
+		byteOrder.PutUint16(data[pos:pos+2], a.TrackIndex)
+		copy(data[pos+2:pos+14], a.UID)
+		copy(data[pos+14:pos+28], a.TrackFormatRef)
+		copy(data[pos+28:pos+39], a.PackFormatRef)
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(CHNAID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeChnaChunk returns encoded chunk 'chna' containing provided audio ids.
+func EncodeChnaChunk(audioIDs []*ChnaAudioID) *Chna {
+	header := EncodeChunkHeader(CreateFourCC(CHNAID), uint32(4+chnaAudioIDSize*len(audioIDs)), binary.LittleEndian)
+
+	return &Chna{Header: header, audioIDs: audioIDs}
+}
+
+// DecodeChnaChunk decodes provided byte array to Chna.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// number of tracks - 2 bytes
+// number of UIDs - 2 bytes
+// audio ids - 40 bytes each
+//
+// All audio ids up to chunk size are decoded, including unused entries reserved for later use.
+func DecodeChnaChunk(data []byte) (*Chna, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	c := &Chna{}
+	byteOrder := binary.LittleEndian
+	c.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	var numTracks, numUIDs uint16
+	fields := []interface{}{&numTracks, &numUIDs}
+
+	for _, f := range fields {
+		err := binary.Read(buf, byteOrder, f)
+
+		if err != nil {
+			return c, err
+		}
+	}
+
+	numEntries := int(numUIDs)
+
+	if slots := (int(c.Size()) - 4) / chnaAudioIDSize; slots > numEntries {
+		numEntries = slots
+	}
+
+	for i := 0; i < numEntries; i++ {
+		a := &ChnaAudioID{}
+		var uid [12]byte
+		var trackFormatRef [14]byte
+		var packFormatRef [11]byte
+		var padding byte
+		fields := []interface{}{&a.TrackIndex, &uid, &trackFormatRef, &packFormatRef, &padding}
+
+		for _, f := range fields {
+			err := binary.Read(buf, byteOrder, f)
+
+			if err != nil {
+				return c, err
+			}
+		}
+
+		a.UID = nullTermToString(uid[:])
+		a.TrackFormatRef = nullTermToString(trackFormatRef[:])
+		a.PackFormatRef = nullTermToString(packFormatRef[:])
+		c.audioIDs = append(c.audioIDs, a)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,47 @@
+package chunk
+
+import "testing"
+
+func TestEncodeChnaChunk(t *testing.T) {
+	chunk := EncodeChnaChunk([]*ChnaAudioID{
+		{TrackIndex: 1, UID: "ATU_00000001", TrackFormatRef: "AT_00010001_01", PackFormatRef: "AP_00010002"},
+		{TrackIndex: 2, UID: "ATU_00000002", TrackFormatRef: "AT_00010002_01", PackFormatRef: "AP_00010002"},
+		{TrackIndex: 2, UID: "ATU_00000003", TrackFormatRef: "AT_00031001_01", PackFormatRef: "AP_00031001"},
+	})
+
+	assertEqual(t, chunk.ID(), CHNAID, "ID")
+	assertEqual(t, chunk.Size(), uint32(124), "Size")
+	assertEqual(t, len(chunk.Bytes()), 132, "Bytes length")
+	assertEqual(t, chunk.NumTracks(), 2, "NumTracks")
+	assertEqual(t, len(chunk.Track(2)), 2, "Track length")
+}
+
+func TestDecodeChnaChunk(t *testing.T) {
+	audioID := ChnaAudioID{TrackIndex: 1, UID: "ATU_00000001", TrackFormatRef: "AT_00010001_01", PackFormatRef: "AP_00010002"}
+	data := EncodeChnaChunk([]*ChnaAudioID{&audioID}).Bytes()
+	chunk, err := DecodeChnaChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(chunk.AudioIDs()), 1, "AudioIDs length")
+	assertEqual(t, *chunk.AudioIDs()[0], audioID, "AudioID")
+
+	_, err = DecodeChnaChunk(data[:len(data)-2])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestDecodeChnaChunkReserved(t *testing.T) {
+	audioID := ChnaAudioID{TrackIndex: 1, UID: "ATU_00000001", TrackFormatRef: "AT_00010001_01", PackFormatRef: "AP_00010002"}
+	data := EncodeChnaChunk([]*ChnaAudioID{&audioID, {}, {}}).Bytes()
+
+	assertEqual(t, data[10], byte(1), "Number of UIDs")
+
+	chunk, err := DecodeChnaChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(chunk.AudioIDs()), 3, "AudioIDs length")
+	assertEqual(t, *chunk.AudioIDs()[0], audioID, "AudioID")
+	assertEqual(t, *chunk.AudioIDs()[2], ChnaAudioID{}, "Reserved AudioID")
+	assertEqual(t, chunk.NumTracks(), 1, "NumTracks")
+	assertEqual(t, string(chunk.Bytes()), string(data), "Bytes")
+}
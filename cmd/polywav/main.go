@@ -0,0 +1,85 @@
+// Command polywav splits polyphonic BWF or AIFF files into mono BWF files named from iXML TRACK_LIST
+// and merges mono BWF or AIFF files into one polyphonic BWF file.
+//
+// Usage:
+//
+//	polywav split [-o dir] poly.wav
+//	polywav merge -o poly.wav mono1.wav mono2.wav ...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/metawav/chunk"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+
+	switch os.Args[1] {
+	case "split":
+		err = split(os.Args[2:])
+	case "merge":
+		err = merge(os.Args[2:])
+	default:
+		usage()
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "polywav: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func split(args []string) error {
+	flags := flag.NewFlagSet("split", flag.ExitOnError)
+	dir := flags.String("o", "", "output directory (default: directory of input file)")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		usage()
+	}
+
+	path := flags.Arg(0)
+
+	if *dir == "" {
+		*dir = filepath.Dir(path)
+	}
+
+	paths, err := chunk.SplitPolyFile(path, *dir)
+
+	if err != nil {
+		return err
+	}
+
+	for _, p := range paths {
+		fmt.Println(p)
+	}
+
+	return nil
+}
+
+func merge(args []string) error {
+	flags := flag.NewFlagSet("merge", flag.ExitOnError)
+	out := flags.String("o", "", "output file")
+	flags.Parse(args)
+
+	if *out == "" || flags.NArg() == 0 {
+		usage()
+	}
+
+	return chunk.MergeMonoFiles(*out, flags.Args())
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: polywav split [-o dir] poly.wav")
+	fmt.Fprintln(os.Stderr, "       polywav merge -o poly.wav mono1.wav mono2.wav ...")
+	os.Exit(2)
+}
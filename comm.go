@@ -9,6 +9,9 @@ import (
 	"github.com/metawav/chunk/internal"
 )
 
+// commAIFFSize is the data size of an AIFF common chunk without compression type and name.
+const commAIFFSize uint32 = 18
+
 // COMM is AIFF / AIFF-C common chunk 'COMM' describing sampled sound in sound chunk 'SSND'.
 type COMM struct {
 	*Header
@@ -116,8 +119,8 @@ func EncodeCOMMChunk(size uint32, numChannels int16, numSamplesPerFrame uint32,
 // num sample frames - 4 bytes
 // sample size - 2 bytes
 // sample rate - 10 bytes 80 bit encoded float with extended precision (IEEE 754)
-// compression type - 4 bytes
-// compression name - max. 256 bytes (1 byte size, max. 255 byte name, max. 1 byte padding)
+// compression type - 4 bytes (AIFF-C only)
+// compression name - max. 256 bytes (1 byte size, max. 255 byte name, max. 1 byte padding) (AIFF-C only)
 func DecodeCOMMChunk(data []byte) (*COMM, error) {
 	if len(data) < int(HeaderSizeBytes) {
 		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
@@ -133,7 +136,12 @@ func DecodeCOMMChunk(data []byte) (*COMM, error) {
 	buf := bytes.NewReader(data[HeaderSizeBytes:])
 	fields := []interface{}{&c.numChannels, &c.numSampleFrames, &c.sampleSize, &c.sampleRate, &c.compressionType, &compressionNameSize}
 
-	for _, f := range fields {
+	for i, f := range fields {
+		// AIFF (not AIFF-C) common chunk ends after sample rate
+		if i == 4 && buf.Len() == 0 && c.Size() == commAIFFSize {
+			return c, nil
+		}
+
 		err := binary.Read(buf, byteOrder, f)
 
 		if err != nil {
@@ -84,6 +84,15 @@ func TestDecodeCommChunk(t *testing.T) {
 	assertEqual(t, chunk.SampleSize(), 3, "SampleSize")
 	assertEqual(t, chunk.CompressionType(), "NONE", "CompressionType")
 	assertEqual(t, chunk.CompressionName(), "no compression", "CompressionName")
+
+	aiffData := make([]byte, HeaderSizeBytes+18)
+	copy(aiffData, EncodeChunkHeader(CreateFourCC(COMMID), 18, binary.BigEndian).Bytes())
+	binary.BigEndian.PutUint16(aiffData[HeaderSizeBytes:HeaderSizeBytes+2], channels)
+	chunk, err = DecodeCOMMChunk(aiffData)
+
+	assertNil(t, err, "err when DecodeCOMMChunk of AIFF common chunk")
+	assertEqual(t, chunk.Channels(), 1, "Channels")
+	assertEqual(t, chunk.CompressionType(), "", "CompressionType")
 }
 
 func TestCommBytes(t *testing.T) {
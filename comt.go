@@ -0,0 +1,192 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// macEpoch is the start of time stamps of AIFF comments.
+var macEpoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// AIFFComment is a comment of AIFF comments chunk 'COMT'.
+type AIFFComment struct {
+	// Timestamp is the creation time of the comment, stored as seconds since 1904-01-01 and interpreted as UTC.
+	Timestamp time.Time
+	// MarkerID links the comment to a marker of chunk 'MARK', 0 if not linked.
+	MarkerID int16
+	// Text is the comment, max. 65535 characters.
+	Text string
+}
+
+// Marker returns the marker of provided chunk 'MARK' the comment is linked to, nil if not linked or not found.
+func (c *AIFFComment) Marker(mark *MARK) *AIFFMarker {
+	if mark == nil || c.MarkerID == 0 {
+		return nil
+	}
+
+	return mark.Marker(c.MarkerID)
+}
+
+// String returns string represensation of comment.
+func (c *AIFFComment) String() string {
+	return fmt.Sprintf("Timestamp: %s Marker ID: %d Text: %s", c.Timestamp.Format(time.RFC3339), c.MarkerID, c.Text)
+}
+
+// COMT is AIFF / AIFF-C comments chunk 'COMT'.
+type COMT struct {
+	*Header
+	comments []*AIFFComment
+}
+
+// Comments returns the comments.
+func (c *COMT) Comments() []*AIFFComment {
+	return c.comments
+}
+
+// SetComments sets the comments.
+func (c *COMT) SetComments(comments []*AIFFComment) {
+	c.comments = comments
+}
+
+// MarkerComments returns the comments linked to provided marker id.
+func (c *COMT) MarkerComments(markerID int16) []*AIFFComment {
+	var comments []*AIFFComment
+
+	for _, comment := range c.comments {
+		if comment.MarkerID == markerID {
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments
+}
+
+// String returns string represensation of chunk.
+func (c *COMT) String() string {
+	lines := make([]string, len(c.comments))
+
+	for i, comment := range c.comments {
+		lines[i] = comment.String()
+	}
+
+	return fmt.Sprintf("Comments: %d\n%s", len(c.comments), strings.Join(lines, "\n"))
+}
+
+// Bytes converts COMT to byte array. A new Header with id 'COMT' is created.
+//
+// Header size is set to real data size. A minimum of 10 bytes is returned.
+// chunk header - 8 bytes
+// number of comments - 2 bytes
+// comments - timestamp (4 bytes), marker id (2 bytes), count (2 bytes), text padded to even size
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (c *COMT) Bytes() []byte {
+	byteOrder := binary.BigEndian
+	data := make([]byte, 2)
+	byteOrder.PutUint16(data, uint16(len(c.comments)))
+
+	for _, comment := range c.comments {
+		text := comment.Text
+
+		if len(text) > 0xFFFF {
+			text = text[:0xFFFF]
+		}
+
+		values := make([]byte, 8)
+		byteOrder.PutUint32(values[0:4], macTimestamp(comment.Timestamp))
+		byteOrder.PutUint16(values[4:6], uint16(comment.MarkerID))
+		byteOrder.PutUint16(values[6:8], uint16(len(text)))
+		data = append(data, values...)
+		data = append(data, pad([]byte(text))...)
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(COMTID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeCOMTChunk returns encoded chunk 'COMT' containing provided comments.
+func EncodeCOMTChunk(comments []*AIFFComment) *COMT {
+	c := &COMT{comments: comments}
+	c.Header = decodeChunkHeader(c.Bytes(), 0, binary.BigEndian)
+
+	return c
+}
+
+// DecodeCOMTChunk decodes provided byte array to COMT.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// number of comments - 2 bytes
+// comments - not restricted amount of bytes
+func DecodeCOMTChunk(data []byte) (*COMT, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	c := &COMT{}
+	byteOrder := binary.BigEndian
+	c.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	var count uint16
+	err := binary.Read(buf, byteOrder, &count)
+
+	if err != nil {
+		return c, err
+	}
+
+	for i := uint16(0); i < count; i++ {
+		comment := &AIFFComment{}
+		var timestamp uint32
+		var size uint16
+		fields := []interface{}{&timestamp, &comment.MarkerID, &size}
+
+		for _, f := range fields {
+			err := binary.Read(buf, byteOrder, f)
+
+			if err != nil {
+				return c, err
+			}
+		}
+
+		text := make([]byte, size)
+		_, err = io.ReadFull(buf, text)
+
+		if err != nil {
+			return c, err
+		}
+
+		// text is padded to even size
+		if size%2 != 0 {
+			buf.ReadByte()
+		}
+
+		comment.Timestamp = macEpoch.Add(time.Duration(timestamp) * time.Second)
+		comment.Text = string(text)
+		c.comments = append(c.comments, comment)
+	}
+
+	return c, nil
+}
+
+// macTimestamp returns provided time as seconds since 1904-01-01, clamped to the range of 32 bit.
+func macTimestamp(t time.Time) uint32 {
+	seconds := t.Unix() - macEpoch.Unix()
+
+	if seconds < 0 {
+		return 0
+	}
+
+	if seconds > 0xFFFFFFFF {
+		return 0xFFFFFFFF
+	}
+
+	return uint32(seconds)
+}
@@ -0,0 +1,39 @@
+package chunk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncodeCOMTChunk(t *testing.T) {
+	chunk := EncodeCOMTChunk([]*AIFFComment{{Timestamp: time.Unix(0, 0), Text: "odd"}, {Timestamp: time.Unix(0, 0), MarkerID: 2, Text: "even"}})
+
+	assertEqual(t, chunk.ID(), COMTID, "ID")
+	assertEqual(t, chunk.Size(), uint32(2+8+4+8+4), "Size")
+	assertEqual(t, len(chunk.MarkerComments(2)), 1, "MarkerComments length")
+	assertEqual(t, macTimestamp(time.Unix(0, 0)), uint32(2082844800), "macTimestamp")
+	assertEqual(t, macTimestamp(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)), uint32(0), "macTimestamp before epoch")
+}
+
+func TestDecodeCOMTChunk(t *testing.T) {
+	timestamp := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+	data := EncodeCOMTChunk([]*AIFFComment{{Timestamp: timestamp, MarkerID: 1, Text: "odd"}, {Timestamp: timestamp, Text: "second"}}).Bytes()
+	chunk, err := DecodeCOMTChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(chunk.Comments()), 2, "Comments length")
+	assertEqual(t, chunk.Comments()[0].Timestamp.Equal(timestamp), true, "Timestamp")
+	assertEqual(t, chunk.Comments()[0].MarkerID, int16(1), "MarkerID")
+	assertEqual(t, chunk.Comments()[0].Text, "odd", "Text")
+	assertEqual(t, chunk.Comments()[1].Text, "second", "Text")
+
+	mark := EncodeMARKChunk([]*AIFFMarker{{ID: 1, Position: 100, Name: "start"}})
+
+	assertEqual(t, chunk.Comments()[0].Marker(mark).Position, uint32(100), "Marker")
+	assertEqual(t, chunk.Comments()[1].Marker(mark), (*AIFFMarker)(nil), "Marker not linked")
+	assertEqual(t, chunk.Comments()[0].Marker(nil), (*AIFFMarker)(nil), "Marker without MARK")
+
+	_, err = DecodeCOMTChunk(data[:len(data)-4])
+
+	assertNotNil(t, err, "err with short data")
+}
@@ -11,12 +11,76 @@ const (
 	FormatSizeBytes uint32 = 4
 	// ContainerHeaderSizeBytes is byte size of the container header
 	ContainerHeaderSizeBytes uint32 = HeaderSizeBytes + FormatSizeBytes
+	// Acid chunk ID
+	ACIDID = "acid"
+	// Annotation chunk ID (AIFF)
+	ANNOID = "ANNO"
+	// Application specific chunk ID (AIFF)
+	APPLID = "APPL"
+	// Associated data list type
+	ADTLID = "adtl"
+	// Author chunk ID (AIFF)
+	AUTHID = "AUTH"
+	// ADM XML chunk ID
+	AXMLID = "axml"
 	// Bext chunk ID
 	BEXTID = "bext"
+	// Compressed XML chunk ID
+	BXMLID = "bxml"
+	// Cart chunk ID
+	CARTID = "cart"
+	// Channel allocation chunk ID
+	CHNAID = "chna"
+	// Comments chunk ID (AIFF)
+	COMTID = "COMT"
+	// Copyright chunk ID (AIFF)
+	COPYRIGHTID = "(c) "
 	// Common chunk ID
 	COMMID = "COMM"
+	// Cue chunk ID
+	CUEID = "cue "
+	// Data chunk ID
+	DATAID = "data"
+	// Dolby metadata chunk ID
+	DBMDID = "dbmd"
+	// Fact chunk ID
+	FACTID = "fact"
 	// Format chunk ID
 	FMTID = "fmt "
+	// Format version chunk ID (AIFF-C)
+	FVERID = "FVER"
+	// ID3v2 chunk ID (AIFF and RIFF)
+	ID3ID = "ID3 "
+	// ID3v2 chunk ID (RIFF)
+	ID3RIFFID = "id3 "
+	// Info list type
+	INFOID = "INFO"
+	// Instrument chunk ID (RIFF)
+	INSTID = "inst"
+	// Instrument chunk ID (AIFF)
+	INSTRUMENTID = "INST"
 	// iXML chunk ID
 	IXMLID = "iXML"
+	// Peak envelope chunk ID
+	LEVLID = "levl"
+	// Link chunk ID
+	LINKID = "link"
+	// List chunk ID
+	LISTID = "LIST"
+	// Marker chunk ID
+	MARKID = "MARK"
+	// MD5 checksum chunk ID
+	MD5ID = "MD5 "
+	// Name chunk ID (AIFF)
+	NAMEID = "NAME"
+	// Peak chunk ID
+	PEAKID = "PEAK"
+	// XMP chunk ID (RIFF)
+	PMXID = "_PMX"
+	// Quality chunk ID
+	QLTYID = "qlty"
+	// Sampler chunk ID
+	SMPLID = "smpl"
+	// Sound data chunk ID
+	SSNDID = "SSND"
 )
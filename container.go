@@ -26,6 +26,19 @@ func (c *Container) FindHeaders(id string) []*Header {
 	return headers
 }
 
+// ReadChunk reads the chunk described by provided header including chunk header bytes.
+// The returned byte array can be passed to the Decode functions of known chunks.
+func ReadChunk(reader io.ReaderAt, header *Header) ([]byte, error) {
+	data := make([]byte, HeaderSizeBytes+header.Size())
+	n, err := reader.ReadAt(data, int64(header.StartPos()))
+
+	if n == len(data) {
+		return data, nil
+	}
+
+	return data[:n], err
+}
+
 // ReadAiff reads a AIFF / AIFF-C file from provided byte stream and assigns provided name.
 func ReadAiff(name string, reader io.ReadSeeker) (*Container, error) {
 	return read(name, reader, binary.BigEndian)
@@ -36,6 +49,28 @@ func ReadRiff(name string, reader io.ReadSeeker) (*Container, error) {
 	return read(name, reader, binary.LittleEndian)
 }
 
+// readContainer reads a RIFF or AIFF / AIFF-C file from provided byte stream, the type is detected by the container id.
+func readContainer(name string, reader io.ReadSeeker) (*Container, error) {
+	var id [IDSizeBytes]byte
+	_, err := io.ReadFull(reader, id[:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = reader.Seek(-int64(IDSizeBytes), io.SeekCurrent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if string(id[:]) == "FORM" {
+		return ReadAiff(name, reader)
+	}
+
+	return ReadRiff(name, reader)
+}
+
 // Read
 func read(name string, reader io.ReadSeeker, byteOrder binary.ByteOrder) (*Container, error) {
 	containerHeader, err := readContainerHeader(reader, byteOrder)
@@ -0,0 +1,156 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// cuePointSize is the byte size of a cue point.
+const cuePointSize = 24
+
+// CuePoint is a position in the sound data marked by a cue chunk 'cue '.
+type CuePoint struct {
+	// ID is the unique identifier of the cue point, referenced by chunk 'LIST' of type 'adtl'.
+	ID uint32
+	// Position is the sample position of the cue point in play order.
+	Position uint32
+	// DataChunkID is the id of the chunk containing the cue point, 'data' or 'slnt' of a wave list.
+	DataChunkID string
+	// ChunkStart is the byte position of the chunk containing the cue point in a wave list, 0 for 'data'.
+	ChunkStart uint32
+	// BlockStart is the byte position of the block containing the cue point for compressed data, 0 for PCM.
+	BlockStart uint32
+	// SampleOffset is the sample frame offset of the cue point relative to the start of the block.
+	SampleOffset uint32
+}
+
+// String returns string represensation of cue point.
+func (p *CuePoint) String() string {
+	return fmt.Sprintf("ID: %d Position: %d Data chunk ID: %s Chunk start: %d Block start: %d Sample offset: %d",
+		p.ID, p.Position, p.DataChunkID, p.ChunkStart, p.BlockStart, p.SampleOffset)
+}
+
+// Cue is RIFF cue chunk 'cue ' containing cue points marking positions in the sound data.
+type Cue struct {
+	*Header
+	points []*CuePoint
+}
+
+// Points returns the cue points.
+func (c *Cue) Points() []*CuePoint {
+	return c.points
+}
+
+// SetPoints sets the cue points.
+func (c *Cue) SetPoints(points []*CuePoint) {
+	c.points = points
+}
+
+// String returns string represensation of chunk.
+func (c *Cue) String() string {
+	lines := make([]string, len(c.points))
+
+	for i, p := range c.points {
+		lines[i] = p.String()
+	}
+
+	return fmt.Sprintf("Cue points: %d\n%s", len(c.points), strings.Join(lines, "\n"))
+}
+
+// Bytes converts Cue to byte array. A new Header with id 'cue ' is created.
+//
+// Header size is set to real data size. A minimum amount of 12 bytes is returned.
+// chunk header - 8 bytes
+// number of cue points - 4 bytes
+// cue points - 24 bytes each: id, position, data chunk id, chunk start, block start, sample offset
+func (c *Cue) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	data := make([]byte, 4+cuePointSize*len(c.points))
+	byteOrder.PutUint32(data[0:4], uint32(len(c.points)))
+
+	for i, p := range c.points {
+		pos := 4 + i*cuePointSize
+		dataChunkID := CreateFourCC(p.DataChunkID)
+		byteOrder.PutUint32(data[pos:pos+4], p.ID)
+		byteOrder.PutUint32(data[pos+4:pos+8], p.Position)
+		copy(data[pos+8:pos+12], dataChunkID[:])
+		byteOrder.PutUint32(data[pos+12:pos+16], p.ChunkStart)
+		byteOrder.PutUint32(data[pos+16:pos+20], p.BlockStart)
+		byteOrder.PutUint32(data[pos+20:pos+24], p.SampleOffset)
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(CUEID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeCueChunk returns encoded chunk 'cue ' containing provided cue points.
+func EncodeCueChunk(points []*CuePoint) *Cue {
+	header := EncodeChunkHeader(CreateFourCC(CUEID), uint32(4+cuePointSize*len(points)), binary.LittleEndian)
+
+	return &Cue{Header: header, points: points}
+}
+
+// DecodeCueChunk decodes provided byte array to Cue.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// number of cue points - 4 bytes
+// cue points - 24 bytes each
+func DecodeCueChunk(data []byte) (*Cue, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	c := &Cue{}
+	byteOrder := binary.LittleEndian
+	c.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	var count uint32
+	err := binary.Read(buf, byteOrder, &count)
+
+	if err != nil {
+		return c, err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		p := &CuePoint{}
+		var dataChunkID [4]byte
+		fields := []interface{}{&p.ID, &p.Position, &dataChunkID, &p.ChunkStart, &p.BlockStart, &p.SampleOffset}
+
+		for _, f := range fields {
+			err := binary.Read(buf, byteOrder, f)
+
+			if err != nil {
+				return c, err
+			}
+		}
+
+		p.DataChunkID = string(dataChunkID[:])
+		c.points = append(c.points, p)
+	}
+
+	return c, nil
+}
+
+func readCue(reader io.ReaderAt, container *Container) (*Cue, error) {
+	headers := container.FindHeaders(CUEID)
+
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	data, err := ReadChunk(reader, headers[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeCueChunk(data)
+}
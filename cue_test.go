@@ -0,0 +1,37 @@
+package chunk
+
+import "testing"
+
+func TestEncodeCueChunk(t *testing.T) {
+	chunk := EncodeCueChunk([]*CuePoint{{ID: 1, Position: 100, DataChunkID: DATAID, SampleOffset: 100}, {ID: 2, Position: 200, DataChunkID: DATAID, SampleOffset: 200}})
+
+	assertEqual(t, chunk.ID(), CUEID, "ID")
+	assertEqual(t, chunk.Size(), uint32(4+2*24), "Size")
+	assertEqual(t, len(chunk.Bytes()), 8+4+2*24, "Bytes length")
+	assertEqual(t, len(chunk.Points()), 2, "Points length")
+}
+
+func TestDecodeCueChunk(t *testing.T) {
+	data := EncodeCueChunk([]*CuePoint{{ID: 7, Position: 1, DataChunkID: "slnt", ChunkStart: 2, BlockStart: 3, SampleOffset: 4}}).Bytes()
+	chunk, err := DecodeCueChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(chunk.Points()), 1, "Points length")
+
+	p := chunk.Points()[0]
+
+	assertEqual(t, p.ID, uint32(7), "ID")
+	assertEqual(t, p.Position, uint32(1), "Position")
+	assertEqual(t, p.DataChunkID, "slnt", "DataChunkID")
+	assertEqual(t, p.ChunkStart, uint32(2), "ChunkStart")
+	assertEqual(t, p.BlockStart, uint32(3), "BlockStart")
+	assertEqual(t, p.SampleOffset, uint32(4), "SampleOffset")
+
+	_, err = DecodeCueChunk(data[:len(data)-1])
+
+	assertNotNil(t, err, "err with incomplete cue point")
+
+	_, err = DecodeCueChunk(data[:HeaderSizeBytes-1])
+
+	assertNotNil(t, err, "err with short data")
+}
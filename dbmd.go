@@ -0,0 +1,490 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// DbmdDolbyE is the id of the Dolby E metadata segment.
+	DbmdDolbyE uint8 = 1
+	// DbmdDolbyDigital is the id of the Dolby Digital metadata segment.
+	DbmdDolbyDigital uint8 = 3
+	// DbmdDolbyDigitalPlus is the id of the Dolby Digital Plus metadata segment.
+	DbmdDolbyDigitalPlus uint8 = 7
+	// DbmdAudioInfo is the id of the audio info segment.
+	DbmdAudioInfo uint8 = 8
+	// DbmdDolbyAtmos is the id of the Dolby Atmos metadata segment.
+	DbmdDolbyAtmos uint8 = 9
+	// dolbyDigitalMinSize is the byte size of the Dolby Digital fields decoded by DolbyDigitalMetadata.
+	dolbyDigitalMinSize = 9
+	// dolbyDigitalPlusMinSize is the byte size of the Dolby Digital Plus fields decoded by DolbyDigitalPlusMetadata.
+	dolbyDigitalPlusMinSize = 9
+)
+
+// DbmdSegment is a metadata segment of chunk 'dbmd'. The payload is preserved as read.
+type DbmdSegment struct {
+	ID   uint8
+	Data []byte
+}
+
+// checksum is the two's complement of the sum of the size and payload bytes of the segment.
+func (s *DbmdSegment) checksum() uint8 {
+	sum := uint8(len(s.Data)) + uint8(len(s.Data)>>8)
+
+	for _, b := range s.Data {
+		sum += b
+	}
+
+	return -sum
+}
+
+// Dbmd is chunk 'dbmd' containing Dolby metadata segments, e.g. Dolby E, Dolby Digital and Dolby Digital Plus,
+// as specified in EBU Tech 3285 Supplement 6.
+type Dbmd struct {
+	*Header
+	version  uint32
+	segments []*DbmdSegment
+}
+
+// Version is the version of the metadata, e.g. 0x01000006.
+func (d *Dbmd) Version() uint32 {
+	return d.version
+}
+
+// SetVersion
+func (d *Dbmd) SetVersion(value uint32) {
+	d.version = value
+}
+
+// Segments returns the metadata segments.
+func (d *Dbmd) Segments() []*DbmdSegment {
+	return d.segments
+}
+
+// SetSegments sets the metadata segments.
+func (d *Dbmd) SetSegments(segments []*DbmdSegment) {
+	d.segments = segments
+}
+
+// Segment returns the first metadata segment of provided id, nil if not found.
+func (d *Dbmd) Segment(id uint8) *DbmdSegment {
+	for _, s := range d.segments {
+		if s.ID == id {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// DolbyDigital returns the fields of the Dolby Digital metadata segment, nil if not found.
+func (d *Dbmd) DolbyDigital() *DolbyDigitalMetadata {
+	return newDolbyDigitalMetadata(d.Segment(DbmdDolbyDigital))
+}
+
+// DolbyDigitalPlus returns the fields of the Dolby Digital Plus metadata segment, nil if not found.
+func (d *Dbmd) DolbyDigitalPlus() *DolbyDigitalPlusMetadata {
+	return newDolbyDigitalPlusMetadata(d.Segment(DbmdDolbyDigitalPlus))
+}
+
+// String returns string represensation of chunk.
+func (d *Dbmd) String() string {
+	lines := []string{fmt.Sprintf("Version: %08X", d.version)}
+
+	for _, s := range d.segments {
+		lines = append(lines, fmt.Sprintf("Segment %d: %d bytes", s.ID, len(s.Data)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Bytes converts Dbmd to byte array. A new Header with id 'dbmd' is created.
+//
+// Header size is set to real data size. A minimum amount of 13 bytes is returned.
+// chunk header - 8 bytes
+// version - 4 bytes
+// segments - id (1 byte), size (2 bytes), payload (size bytes), checksum (1 byte) each
+// end of segments - 1 byte with value 0
+//
+// Checksums are recalculated. A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (d *Dbmd) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	data := make([]byte, 4)
+	byteOrder.PutUint32(data, d.version)
+
+	for _, s := range d.segments {
+		size := make([]byte, 2)
+		byteOrder.PutUint16(size, uint16(len(s.Data)))
+		data = append(data, s.ID)
+		data = append(data, size...)
+		data = append(data, s.Data...)
+		data = append(data, s.checksum())
+	}
+
+	data = append(data, 0)
+	header := EncodeChunkHeader(CreateFourCC(DBMDID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeDbmdChunk returns encoded chunk 'dbmd' of provided version containing provided segments.
+func EncodeDbmdChunk(version uint32, segments []*DbmdSegment) *Dbmd {
+	size := 5
+
+	for _, s := range segments {
+		size += 4 + len(s.Data)
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(DBMDID), uint32(size), binary.LittleEndian)
+
+	return &Dbmd{Header: header, version: version, segments: segments}
+}
+
+// DecodeDbmdChunk decodes provided byte array to Dbmd. Stored checksums are not verified.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// version - 4 bytes (min. requirement for successful decoding)
+// segments - id (1 byte), size (2 bytes), payload (size bytes), checksum (1 byte) each
+// end of segments - 1 byte with value 0
+func DecodeDbmdChunk(data []byte) (*Dbmd, error) {
+	if len(data) < int(HeaderSizeBytes)+4 {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", int(HeaderSizeBytes)+4)
+		return nil, errors.New(msg)
+	}
+
+	d := &Dbmd{}
+	byteOrder := binary.LittleEndian
+	d.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	err := binary.Read(buf, byteOrder, &d.version)
+
+	if err != nil {
+		return d, err
+	}
+
+	for {
+		var id uint8
+		err = binary.Read(buf, byteOrder, &id)
+
+		// the end of segments marker is missing in some files
+		if err != nil || id == 0 {
+			return d, nil
+		}
+
+		var size uint16
+		var checksum uint8
+		s := &DbmdSegment{ID: id}
+		err = binary.Read(buf, byteOrder, &size)
+
+		if err != nil {
+			return d, err
+		}
+
+		s.Data = make([]byte, size)
+		fields := []interface{}{&s.Data, &checksum}
+
+		for _, f := range fields {
+			err = binary.Read(buf, byteOrder, f)
+
+			if err != nil {
+				return d, err
+			}
+		}
+
+		d.segments = append(d.segments, s)
+	}
+}
+
+// dolbyPayload gives bit access to the payload of a metadata segment.
+type dolbyPayload struct {
+	segment *DbmdSegment
+}
+
+func (p dolbyPayload) bits(pos int, shift uint, mask uint8) uint8 {
+	return p.segment.Data[pos] >> shift & mask
+}
+
+func (p dolbyPayload) setBits(pos int, shift uint, mask uint8, value uint8) {
+	p.segment.Data[pos] = p.segment.Data[pos]&^(mask<<shift) | (value&mask)<<shift
+}
+
+// dialnorm returns the dialogue level in dBFS of the 5 bit code at provided position.
+func (p dolbyPayload) dialnorm(pos int) int {
+	value := int(p.bits(pos, 0, 0x1F))
+
+	// value 0 is reserved and interpreted as -31
+	if value == 0 {
+		value = 31
+	}
+
+	return -value
+}
+
+// setDialnorm sets the 5 bit dialnorm code at provided position, value is clamped to -31 to -1.
+func (p dolbyPayload) setDialnorm(pos int, value int) {
+	if value > -1 {
+		value = -1
+	}
+
+	if value < -31 {
+		value = -31
+	}
+
+	p.setBits(pos, 0, 0x1F, uint8(-value))
+}
+
+// DolbyDigitalMetadata gives access to the fields of a Dolby Digital (AC-3) metadata segment.
+// Fields are read from and written to the payload of the segment, further fields are preserved.
+// Values are interpreted as the bit stream information fields of the same name in ATSC A/52.
+//
+// byte 0 - program id
+// byte 1 - bitstream mode bsmod (bits 7-5), audio coding mode acmod (bits 4-2), LFE on lfeon (bit 0)
+// byte 2 - center mix level cmixlev (bits 7-6), surround mix level surmixlev (bits 5-4), Dolby Surround mode dsurmod (bits 3-2)
+// byte 3 - dialnorm (bits 4-0)
+// byte 4 - line mode compression profile
+// byte 5 - RF mode compression profile
+// byte 6 - Lt/Rt center mix level ltrtcmixlev (bits 5-3), Lt/Rt surround mix level ltrtsurmixlev (bits 2-0)
+// byte 7 - Lo/Ro center mix level lorocmixlev (bits 5-3), Lo/Ro surround mix level lorosurmixlev (bits 2-0)
+// byte 8 - preferred downmix mode dmixmod (bits 1-0)
+type DolbyDigitalMetadata struct {
+	dolbyPayload
+}
+
+func newDolbyDigitalMetadata(segment *DbmdSegment) *DolbyDigitalMetadata {
+	if segment == nil || len(segment.Data) < dolbyDigitalMinSize {
+		return nil
+	}
+
+	return &DolbyDigitalMetadata{dolbyPayload{segment: segment}}
+}
+
+// ProgramID is the id of the program described by the segment.
+func (m *DolbyDigitalMetadata) ProgramID() uint8 {
+	return m.segment.Data[0]
+}
+
+// BitstreamMode is the type of service, e.g. 0 complete main, 1 music and effects, 2 visually impaired.
+func (m *DolbyDigitalMetadata) BitstreamMode() uint8 {
+	return m.bits(1, 5, 0x07)
+}
+
+// AudioCodingMode is the channel layout of the full bandwidth channels, e.g. 2 for 2/0 (L, R), 7 for 3/2 (L, C, R, Ls, Rs).
+func (m *DolbyDigitalMetadata) AudioCodingMode() uint8 {
+	return m.bits(1, 2, 0x07)
+}
+
+// LFE is true if the low frequency effects channel is present.
+func (m *DolbyDigitalMetadata) LFE() bool {
+	return m.bits(1, 0, 0x01) == 1
+}
+
+// Dialnorm is the dialogue level in dBFS from -31 to -1.
+func (m *DolbyDigitalMetadata) Dialnorm() int {
+	return m.dialnorm(3)
+}
+
+// SetDialnorm sets the dialogue level in dBFS, clamped to -31 to -1.
+func (m *DolbyDigitalMetadata) SetDialnorm(value int) {
+	m.setDialnorm(3, value)
+}
+
+// LineModeProfile is the line mode compression profile: 0 none, 1 film standard, 2 film light,
+// 3 music standard, 4 music light, 5 speech.
+func (m *DolbyDigitalMetadata) LineModeProfile() uint8 {
+	return m.segment.Data[4]
+}
+
+// SetLineModeProfile
+func (m *DolbyDigitalMetadata) SetLineModeProfile(value uint8) {
+	m.segment.Data[4] = value
+}
+
+// RFModeProfile is the RF mode compression profile, see LineModeProfile.
+func (m *DolbyDigitalMetadata) RFModeProfile() uint8 {
+	return m.segment.Data[5]
+}
+
+// SetRFModeProfile
+func (m *DolbyDigitalMetadata) SetRFModeProfile(value uint8) {
+	m.segment.Data[5] = value
+}
+
+// DolbySurroundMode is 0 not indicated, 1 not Dolby Surround encoded, 2 Dolby Surround encoded.
+func (m *DolbyDigitalMetadata) DolbySurroundMode() uint8 {
+	return m.bits(2, 2, 0x03)
+}
+
+// CenterMixLevel is the level of the center channel in a stereo downmix in dB: -3, -4.5 or -6.
+func (m *DolbyDigitalMetadata) CenterMixLevel() float64 {
+	// reserved code 3 is interpreted as -4.5 dB
+	return []float64{-3, -4.5, -6, -4.5}[m.bits(2, 6, 0x03)]
+}
+
+// SurroundMixLevel is the level of the surround channels in a stereo downmix in dB: -3, -6 or -Inf.
+func (m *DolbyDigitalMetadata) SurroundMixLevel() float64 {
+	// reserved code 3 is interpreted as -6 dB
+	return []float64{-3, -6, math.Inf(-1), -6}[m.bits(2, 4, 0x03)]
+}
+
+// LtRtCenterMixLevel is the level of the center channel in a Lt/Rt downmix in dB.
+func (m *DolbyDigitalMetadata) LtRtCenterMixLevel() float64 {
+	return dolbyCenterMixLevel(m.bits(6, 3, 0x07))
+}
+
+// LtRtSurroundMixLevel is the level of the surround channels in a Lt/Rt downmix in dB.
+func (m *DolbyDigitalMetadata) LtRtSurroundMixLevel() float64 {
+	return dolbySurroundMixLevel(m.bits(6, 0, 0x07))
+}
+
+// LoRoCenterMixLevel is the level of the center channel in a Lo/Ro downmix in dB.
+func (m *DolbyDigitalMetadata) LoRoCenterMixLevel() float64 {
+	return dolbyCenterMixLevel(m.bits(7, 3, 0x07))
+}
+
+// LoRoSurroundMixLevel is the level of the surround channels in a Lo/Ro downmix in dB.
+func (m *DolbyDigitalMetadata) LoRoSurroundMixLevel() float64 {
+	return dolbySurroundMixLevel(m.bits(7, 0, 0x07))
+}
+
+// PreferredDownmix is the preferred stereo downmix mode: 0 not indicated, 1 Lt/Rt, 2 Lo/Ro.
+func (m *DolbyDigitalMetadata) PreferredDownmix() uint8 {
+	return m.bits(8, 0, 0x03)
+}
+
+// String returns string represensation of the metadata.
+func (m *DolbyDigitalMetadata) String() string {
+	return fmt.Sprintf("Program ID: %d\nBitstream mode: %d\nAudio coding mode: %d\nLFE: %t\nDialnorm: %d dB\nLine mode profile: %d\nRF mode profile: %d\nCenter mix level: %.1f dB\nSurround mix level: %.1f dB\nLt/Rt: %.1f / %.1f dB\nLo/Ro: %.1f / %.1f dB\nPreferred downmix: %d",
+		m.ProgramID(), m.BitstreamMode(), m.AudioCodingMode(), m.LFE(), m.Dialnorm(), m.LineModeProfile(), m.RFModeProfile(),
+		m.CenterMixLevel(), m.SurroundMixLevel(), m.LtRtCenterMixLevel(), m.LtRtSurroundMixLevel(), m.LoRoCenterMixLevel(), m.LoRoSurroundMixLevel(), m.PreferredDownmix())
+}
+
+// DolbyDigitalPlusMetadata gives access to the fields of a Dolby Digital Plus (E-AC-3) metadata segment.
+// Fields are read from and written to the payload of the segment, further fields are preserved.
+// Values are interpreted as the bit stream information fields of the same name in ATSC A/52 Annex E.
+// E-AC-3 signals downmix levels only by the 3 bit Lt/Rt and Lo/Ro codes and adds an LFE mix level.
+//
+// byte 0 - program id
+// byte 1 - bitstream mode bsmod (bits 7-5), audio coding mode acmod (bits 4-2), LFE on lfeon (bit 0)
+// byte 2 - dialnorm (bits 4-0)
+// byte 3 - line mode compression profile
+// byte 4 - RF mode compression profile
+// byte 5 - Lt/Rt center mix level ltrtcmixlev (bits 5-3), Lt/Rt surround mix level ltrtsurmixlev (bits 2-0)
+// byte 6 - Lo/Ro center mix level lorocmixlev (bits 5-3), Lo/Ro surround mix level lorosurmixlev (bits 2-0)
+// byte 7 - preferred downmix mode dmixmod (bits 1-0)
+// byte 8 - LFE mix level lfemixlevcod (bits 4-0)
+type DolbyDigitalPlusMetadata struct {
+	dolbyPayload
+}
+
+func newDolbyDigitalPlusMetadata(segment *DbmdSegment) *DolbyDigitalPlusMetadata {
+	if segment == nil || len(segment.Data) < dolbyDigitalPlusMinSize {
+		return nil
+	}
+
+	return &DolbyDigitalPlusMetadata{dolbyPayload{segment: segment}}
+}
+
+// ProgramID is the id of the program described by the segment.
+func (m *DolbyDigitalPlusMetadata) ProgramID() uint8 {
+	return m.segment.Data[0]
+}
+
+// BitstreamMode is the type of service, e.g. 0 complete main, 1 music and effects, 2 visually impaired.
+func (m *DolbyDigitalPlusMetadata) BitstreamMode() uint8 {
+	return m.bits(1, 5, 0x07)
+}
+
+// AudioCodingMode is the channel layout of the full bandwidth channels, e.g. 2 for 2/0 (L, R), 7 for 3/2 (L, C, R, Ls, Rs).
+func (m *DolbyDigitalPlusMetadata) AudioCodingMode() uint8 {
+	return m.bits(1, 2, 0x07)
+}
+
+// LFE is true if the low frequency effects channel is present.
+func (m *DolbyDigitalPlusMetadata) LFE() bool {
+	return m.bits(1, 0, 0x01) == 1
+}
+
+// Dialnorm is the dialogue level in dBFS from -31 to -1.
+func (m *DolbyDigitalPlusMetadata) Dialnorm() int {
+	return m.dialnorm(2)
+}
+
+// SetDialnorm sets the dialogue level in dBFS, clamped to -31 to -1.
+func (m *DolbyDigitalPlusMetadata) SetDialnorm(value int) {
+	m.setDialnorm(2, value)
+}
+
+// LineModeProfile is the line mode compression profile, see DolbyDigitalMetadata.LineModeProfile.
+func (m *DolbyDigitalPlusMetadata) LineModeProfile() uint8 {
+	return m.segment.Data[3]
+}
+
+// SetLineModeProfile
+func (m *DolbyDigitalPlusMetadata) SetLineModeProfile(value uint8) {
+	m.segment.Data[3] = value
+}
+
+// RFModeProfile is the RF mode compression profile, see DolbyDigitalMetadata.LineModeProfile.
+func (m *DolbyDigitalPlusMetadata) RFModeProfile() uint8 {
+	return m.segment.Data[4]
+}
+
+// SetRFModeProfile
+func (m *DolbyDigitalPlusMetadata) SetRFModeProfile(value uint8) {
+	m.segment.Data[4] = value
+}
+
+// LtRtCenterMixLevel is the level of the center channel in a Lt/Rt downmix in dB.
+func (m *DolbyDigitalPlusMetadata) LtRtCenterMixLevel() float64 {
+	return dolbyCenterMixLevel(m.bits(5, 3, 0x07))
+}
+
+// LtRtSurroundMixLevel is the level of the surround channels in a Lt/Rt downmix in dB.
+func (m *DolbyDigitalPlusMetadata) LtRtSurroundMixLevel() float64 {
+	return dolbySurroundMixLevel(m.bits(5, 0, 0x07))
+}
+
+// LoRoCenterMixLevel is the level of the center channel in a Lo/Ro downmix in dB.
+func (m *DolbyDigitalPlusMetadata) LoRoCenterMixLevel() float64 {
+	return dolbyCenterMixLevel(m.bits(6, 3, 0x07))
+}
+
+// LoRoSurroundMixLevel is the level of the surround channels in a Lo/Ro downmix in dB.
+func (m *DolbyDigitalPlusMetadata) LoRoSurroundMixLevel() float64 {
+	return dolbySurroundMixLevel(m.bits(6, 0, 0x07))
+}
+
+// PreferredDownmix is the preferred stereo downmix mode: 0 not indicated, 1 Lt/Rt, 2 Lo/Ro, 3 Pro Logic II.
+func (m *DolbyDigitalPlusMetadata) PreferredDownmix() uint8 {
+	return m.bits(7, 0, 0x03)
+}
+
+// LFEMixLevel is the level of the LFE channel in a downmix in dB from +10 to -21.
+func (m *DolbyDigitalPlusMetadata) LFEMixLevel() float64 {
+	return 10 - float64(m.bits(8, 0, 0x1F))
+}
+
+// String returns string represensation of the metadata.
+func (m *DolbyDigitalPlusMetadata) String() string {
+	return fmt.Sprintf("Program ID: %d\nBitstream mode: %d\nAudio coding mode: %d\nLFE: %t\nDialnorm: %d dB\nLine mode profile: %d\nRF mode profile: %d\nLt/Rt: %.1f / %.1f dB\nLo/Ro: %.1f / %.1f dB\nPreferred downmix: %d\nLFE mix level: %.1f dB",
+		m.ProgramID(), m.BitstreamMode(), m.AudioCodingMode(), m.LFE(), m.Dialnorm(), m.LineModeProfile(), m.RFModeProfile(),
+		m.LtRtCenterMixLevel(), m.LtRtSurroundMixLevel(), m.LoRoCenterMixLevel(), m.LoRoSurroundMixLevel(), m.PreferredDownmix(), m.LFEMixLevel())
+}
+
+// dolbyCenterMixLevel converts the 3 bit code of a Lt/Rt or Lo/Ro center mix level to dB.
+func dolbyCenterMixLevel(code uint8) float64 {
+	return []float64{3, 1.5, 0, -1.5, -3, -4.5, -6, math.Inf(-1)}[code&0x07]
+}
+
+// dolbySurroundMixLevel converts the 3 bit code of a Lt/Rt or Lo/Ro surround mix level to dB.
+// Reserved codes 0 to 2 are interpreted as -1.5 dB.
+func dolbySurroundMixLevel(code uint8) float64 {
+	return []float64{-1.5, -1.5, -1.5, -1.5, -3, -4.5, -6, math.Inf(-1)}[code&0x07]
+}
@@ -0,0 +1,106 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestDbmd(t *testing.T) {
+	dd := make([]byte, 96)
+	dd[1] = 7<<2 | 1 // 3/2 with LFE
+	dd[2] = 1<<6 | 2<<4
+	dd[3] = 24
+	dd[4] = 1
+	dd[6] = 4<<3 | 7
+	dd[8] = 2
+	unknown := &DbmdSegment{ID: 12, Data: []byte{1, 2, 3}}
+	chunk := EncodeDbmdChunk(0x01000006, []*DbmdSegment{{ID: DbmdDolbyDigital, Data: dd}, unknown})
+
+	assertEqual(t, chunk.ID(), DBMDID, "ID")
+
+	data := chunk.Bytes()
+
+	assertEqual(t, binary.LittleEndian.Uint32(data[4:8]), chunk.Size(), "Size")
+	assertEqual(t, len(data), int(HeaderSizeBytes+chunk.Size()), "Bytes length")
+
+	decoded, err := DecodeDbmdChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Version(), uint32(0x01000006), "Version")
+	assertEqual(t, len(decoded.Segments()), 2, "segments length")
+	assertEqual(t, bytes.Equal(decoded.Segment(12).Data, unknown.Data), true, "unknown segment preserved")
+	assertEqual(t, decoded.DolbyDigitalPlus() == nil, true, "DolbyDigitalPlus not present")
+
+	m := decoded.DolbyDigital()
+
+	assertEqual(t, m.AudioCodingMode(), uint8(7), "AudioCodingMode")
+	assertEqual(t, m.LFE(), true, "LFE")
+	assertEqual(t, m.Dialnorm(), -24, "Dialnorm")
+	assertEqual(t, m.LineModeProfile(), uint8(1), "LineModeProfile")
+	assertEqual(t, m.CenterMixLevel(), -4.5, "CenterMixLevel")
+	assertEqual(t, math.IsInf(m.SurroundMixLevel(), -1), true, "SurroundMixLevel")
+	assertEqual(t, m.LtRtCenterMixLevel(), float64(-3), "LtRtCenterMixLevel")
+	assertEqual(t, math.IsInf(m.LtRtSurroundMixLevel(), -1), true, "LtRtSurroundMixLevel")
+	assertEqual(t, m.PreferredDownmix(), uint8(2), "PreferredDownmix")
+
+	m.SetDialnorm(-31)
+	decoded, _ = DecodeDbmdChunk(decoded.Bytes())
+
+	assertEqual(t, decoded.DolbyDigital().Dialnorm(), -31, "Dialnorm after set")
+	assertEqual(t, decoded.DolbyDigital().AudioCodingMode(), uint8(7), "AudioCodingMode after set")
+
+	_, err = DecodeDbmdChunk(data[:HeaderSizeBytes+3])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestDbmdDolbyDigitalPlus(t *testing.T) {
+	ddp := make([]byte, 9)
+	ddp[1] = 2<<5 | 7<<2 | 1
+	ddp[2] = 27
+	ddp[3] = 2
+	ddp[5] = 3<<3 | 1
+	ddp[6] = 6<<3 | 5
+	ddp[7] = 3
+	ddp[8] = 20
+	chunk := EncodeDbmdChunk(0x01000006, []*DbmdSegment{{ID: DbmdDolbyDigitalPlus, Data: ddp}})
+	decoded, _ := DecodeDbmdChunk(chunk.Bytes())
+
+	assertEqual(t, decoded.DolbyDigital() == nil, true, "DolbyDigital not present")
+
+	m := decoded.DolbyDigitalPlus()
+
+	assertEqual(t, m.BitstreamMode(), uint8(2), "BitstreamMode")
+	assertEqual(t, m.AudioCodingMode(), uint8(7), "AudioCodingMode")
+	assertEqual(t, m.LFE(), true, "LFE")
+	assertEqual(t, m.Dialnorm(), -27, "Dialnorm")
+	assertEqual(t, m.LineModeProfile(), uint8(2), "LineModeProfile")
+	assertEqual(t, m.LtRtCenterMixLevel(), -1.5, "LtRtCenterMixLevel")
+	assertEqual(t, m.LtRtSurroundMixLevel(), -1.5, "LtRtSurroundMixLevel of reserved code")
+	assertEqual(t, m.LoRoCenterMixLevel(), float64(-6), "LoRoCenterMixLevel")
+	assertEqual(t, m.LoRoSurroundMixLevel(), -4.5, "LoRoSurroundMixLevel")
+	assertEqual(t, m.PreferredDownmix(), uint8(3), "PreferredDownmix")
+	assertEqual(t, m.LFEMixLevel(), float64(-10), "LFEMixLevel")
+
+	m.SetDialnorm(0)
+
+	assertEqual(t, m.Dialnorm(), -1, "Dialnorm after set")
+	assertEqual(t, m.AudioCodingMode(), uint8(7), "AudioCodingMode after set")
+
+	decoded.Segments()[0].Data = ddp[:8]
+
+	assertEqual(t, decoded.DolbyDigitalPlus() == nil, true, "DolbyDigitalPlus with short payload")
+}
+
+func TestDbmdSegmentChecksum(t *testing.T) {
+	s := &DbmdSegment{ID: DbmdAudioInfo, Data: []byte{1, 2, 3}}
+	var sum uint8 = 3
+
+	for _, b := range s.Data {
+		sum += b
+	}
+
+	assertEqual(t, sum+s.checksum(), uint8(0), "checksum")
+}
@@ -0,0 +1,176 @@
+package chunk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// Chunk is a decoded chunk. Known chunks embed the chunk Header.
+type Chunk interface {
+	ID() string
+	Size() uint32
+}
+
+// Encoder is a known chunk which can be converted to a byte array including chunk header.
+type Encoder interface {
+	Bytes() []byte
+}
+
+// ChunkDecoder decodes a byte array including chunk header to a chunk.
+type ChunkDecoder func(data []byte) (Chunk, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[string]ChunkDecoder{
+		ACIDID:          func(data []byte) (Chunk, error) { return decoded(DecodeAcidChunk(data)) },
+		APPLID:          func(data []byte) (Chunk, error) { return decoded(DecodeApplChunk(data)) },
+		ANNOID:          func(data []byte) (Chunk, error) { return decoded(DecodeAIFFTextChunk(data)) },
+		AUTHID:          func(data []byte) (Chunk, error) { return decoded(DecodeAIFFTextChunk(data)) },
+		AXMLID:          func(data []byte) (Chunk, error) { return decoded(DecodeAXMLChunk(data)) },
+		BEXTID:          func(data []byte) (Chunk, error) { return decoded(DecodeBextChunk(data)) },
+		BXMLID:          func(data []byte) (Chunk, error) { return decoded(DecodeBXMLChunk(data)) },
+		CARTID:          func(data []byte) (Chunk, error) { return decoded(DecodeCartChunk(data)) },
+		CHNAID:          func(data []byte) (Chunk, error) { return decoded(DecodeChnaChunk(data)) },
+		COMMID:          func(data []byte) (Chunk, error) { return decoded(DecodeCOMMChunk(data)) },
+		COMTID:          func(data []byte) (Chunk, error) { return decoded(DecodeCOMTChunk(data)) },
+		COPYRIGHTID:     func(data []byte) (Chunk, error) { return decoded(DecodeAIFFTextChunk(data)) },
+		CUEID:           func(data []byte) (Chunk, error) { return decoded(DecodeCueChunk(data)) },
+		DBMDID:          func(data []byte) (Chunk, error) { return decoded(DecodeDbmdChunk(data)) },
+		FMTID:           decodeFormat,
+		FVERID:          func(data []byte) (Chunk, error) { return decoded(DecodeFVERChunk(data)) },
+		ID3ID:           func(data []byte) (Chunk, error) { return decoded(DecodeID3Chunk(data)) },
+		ID3RIFFID:       func(data []byte) (Chunk, error) { return decoded(DecodeID3Chunk(data)) },
+		INSTID:          func(data []byte) (Chunk, error) { return decoded(DecodeInstChunk(data)) },
+		INSTRUMENTID:    func(data []byte) (Chunk, error) { return decoded(DecodeInstrumentChunk(data)) },
+		IXMLID:          func(data []byte) (Chunk, error) { return decoded(DecodeIXMLChunk(data)) },
+		LEVLID:          func(data []byte) (Chunk, error) { return decoded(DecodeLevlChunk(data)) },
+		LINKID:          func(data []byte) (Chunk, error) { return decoded(DecodeLinkChunk(data)) },
+		LISTID + ADTLID: func(data []byte) (Chunk, error) { return decoded(DecodeAdtlChunk(data)) },
+		LISTID + INFOID: func(data []byte) (Chunk, error) { return decoded(DecodeInfoChunk(data)) },
+		MARKID:          func(data []byte) (Chunk, error) { return decoded(DecodeMARKChunk(data)) },
+		MD5ID:           func(data []byte) (Chunk, error) { return decoded(DecodeMD5Chunk(data)) },
+		NAMEID:          func(data []byte) (Chunk, error) { return decoded(DecodeAIFFTextChunk(data)) },
+		PEAKID:          func(data []byte) (Chunk, error) { return decoded(DecodePeakChunk(data)) },
+		PMXID:           func(data []byte) (Chunk, error) { return decoded(DecodePMXChunk(data)) },
+		QLTYID:          func(data []byte) (Chunk, error) { return decoded(DecodeQltyChunk(data)) },
+		SMPLID:          func(data []byte) (Chunk, error) { return decoded(DecodeSmplChunk(data)) },
+	}
+)
+
+// RegisterDecoder registers provided decoder for chunks of provided id, replacing a registered decoder.
+// Chunks 'LIST' are registered by id followed by list type, e.g. 'LISTINFO'. A nil decoder removes the registration.
+func RegisterDecoder(id string, decoder ChunkDecoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	if decoder == nil {
+		delete(decoders, id)
+		return
+	}
+
+	decoders[id] = decoder
+}
+
+// DecodeChunk decodes provided byte array including chunk header to the known chunk of its id,
+// e.g. *Bext for id 'bext'. Chunks 'LIST' are decoded by id and list type.
+// An error is returned if no decoder is registered for the chunk.
+func DecodeChunk(data []byte) (Chunk, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	key := dataKey(data)
+	decoder := findDecoder(key)
+
+	if decoder == nil {
+		msg := fmt.Sprintf("no decoder registered for chunk '%s'", key)
+		return nil, errors.New(msg)
+	}
+
+	return decoder(data)
+}
+
+// DecodeChunks decodes all chunks of provided container in order. Chunks without a registered decoder,
+// e.g. sound data chunks, are not read and returned as their Header.
+func DecodeChunks(reader io.ReaderAt, container *Container) ([]Chunk, error) {
+	chunks := make([]Chunk, len(container.Headers))
+
+	for i, header := range container.Headers {
+		key, err := chunkKey(reader, header)
+
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := findDecoder(key)
+
+		if decoder == nil {
+			chunks[i] = header
+			continue
+		}
+
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return nil, err
+		}
+
+		chunks[i], err = decoder(data)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return chunks, nil
+}
+
+func findDecoder(key string) ChunkDecoder {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	if decoder, ok := decoders[key]; ok {
+		return decoder
+	}
+
+	// chunks 'APPL' are keyed by signature but decoded by id
+	if len(key) > int(IDSizeBytes) && key[:IDSizeBytes] == APPLID {
+		return decoders[APPLID]
+	}
+
+	return nil
+}
+
+// dataKey returns the id of provided chunk data, for chunks 'LIST' followed by the list type
+// and for chunks 'APPL' followed by the application signature.
+func dataKey(data []byte) string {
+	key := string(data[:IDSizeBytes])
+
+	if (key == LISTID || key == APPLID) && len(data) >= int(ContainerHeaderSizeBytes) {
+		key += string(data[HeaderSizeBytes:ContainerHeaderSizeBytes])
+	}
+
+	return key
+}
+
+// decoded returns a nil Chunk instead of a Chunk holding a nil pointer.
+func decoded(c Chunk, err error) (Chunk, error) {
+	if c == nil || reflect.ValueOf(c).IsNil() {
+		return nil, err
+	}
+
+	return c, err
+}
+
+// decodeFormat decodes chunk 'fmt ' to PCMFormat, to FMT if bits per sample are missing.
+func decodeFormat(data []byte) (Chunk, error) {
+	if len(data) < int(HeaderSizeBytes)+16 {
+		return decoded(DecodeFMTChunk(data))
+	}
+
+	return decoded(DecodePCMFormatChunk(data))
+}
@@ -0,0 +1,70 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestDecodeChunk(t *testing.T) {
+	chunk, err := DecodeChunk(EncodeAcidChunk(AcidStretch, 0, 8, 4, 4, 120).Bytes())
+
+	assertNil(t, err, "err")
+
+	acid, ok := chunk.(*Acid)
+
+	assertEqual(t, ok, true, "type Acid")
+	assertEqual(t, acid.Tempo(), float32(120), "Tempo")
+
+	chunk, err = DecodeChunk(EncodeInfoChunk(map[string]string{InfoName: "name"}).Bytes())
+
+	assertNil(t, err, "err")
+
+	_, ok = chunk.(*Info)
+
+	assertEqual(t, ok, true, "type Info")
+
+	_, err = DecodeChunk(EncodeChunkHeader(CreateFourCC("abcd"), 0, binary.LittleEndian).Bytes())
+
+	assertNotNil(t, err, "err with unknown chunk")
+
+	chunk, err = DecodeChunk(make([]byte, HeaderSizeBytes-1))
+
+	assertNotNil(t, err, "err with short data")
+	assertEqual(t, chunk, nil, "chunk with short data")
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	id := "test"
+	RegisterDecoder(id, func(data []byte) (Chunk, error) {
+		return DecodeChunkHeader([HeaderSizeBytes]byte{}, 0, binary.LittleEndian), nil
+	})
+
+	_, err := DecodeChunk(EncodeChunkHeader(CreateFourCC(id), 0, binary.LittleEndian).Bytes())
+
+	assertNil(t, err, "err")
+
+	RegisterDecoder(id, nil)
+	_, err = DecodeChunk(EncodeChunkHeader(CreateFourCC(id), 0, binary.LittleEndian).Bytes())
+
+	assertNotNil(t, err, "err with removed decoder")
+}
+
+func TestDecodeChunks(t *testing.T) {
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), make([]byte, 4))
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	chunks, err := DecodeChunks(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(chunks), 2, "chunks length")
+
+	format, ok := chunks[0].(*PCMFormat)
+
+	assertEqual(t, ok, true, "type PCMFormat")
+	assertEqual(t, format.BitsPerSample(), 16, "BitsPerSample")
+
+	_, ok = chunks[1].(*Header)
+
+	assertEqual(t, ok, true, "type Header")
+}
@@ -0,0 +1,287 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	wavePCM              = 1
+	waveIEEEFloat        = 3
+	waveFormatExtensible = 0xFFFE
+)
+
+// FrameReader provides random access to the sample frames of the sound data chunk
+// ('data' for RIFF, 'SSND' for AIFF / AIFF-C) of a container.
+// A sample frame contains one sample for each channel and is BlockAlign bytes long.
+//
+// FrameReader does not hold any read state, it is safe for concurrent use by multiple goroutines
+// as long as the underlying io.ReaderAt is (e.g. *os.File).
+type FrameReader struct {
+	reader        io.ReaderAt
+	format        int
+	channels      int
+	bitsPerSample int
+	blockAlign    int
+	sampleRate    int
+	sampleOrder   binary.ByteOrder
+	// validBits is the number of significant bits of a sample, e.g. 24 for 24 bit samples in 32 bit containers.
+	validBits int
+	// unsigned8 is true for WAVE, whose 8 bit samples are unsigned. AIFF samples are always signed.
+	unsigned8 bool
+	dataPos   int64
+	dataSize  int64
+}
+
+// Format is the WAVE format code of the samples: 1 for PCM and 3 for IEEE float.
+// For WAVE_FORMAT_EXTENSIBLE the format code of the sub format is returned.
+// For AIFF-C the format code equivalent to the compression type is returned, 0 if samples are compressed.
+func (fr *FrameReader) Format() int {
+	return fr.format
+}
+
+// Channels is the number of interleaved channels of a sample frame.
+func (fr *FrameReader) Channels() int {
+	return fr.channels
+}
+
+// BitsPerSample is the number of bits of a single sample.
+func (fr *FrameReader) BitsPerSample() int {
+	return fr.bitsPerSample
+}
+
+// BlockAlign is the byte size of a sample frame.
+func (fr *FrameReader) BlockAlign() int {
+	return fr.blockAlign
+}
+
+// SampleRate is the number of sample frames per second.
+func (fr *FrameReader) SampleRate() int {
+	return fr.sampleRate
+}
+
+// DataPos is the absolute byte position of the first sample frame.
+func (fr *FrameReader) DataPos() int64 {
+	return fr.dataPos
+}
+
+// DataSize is the byte size of all sample frames.
+func (fr *FrameReader) DataSize() int64 {
+	return fr.dataSize
+}
+
+// NumFrames is the number of sample frames.
+func (fr *FrameReader) NumFrames() uint64 {
+	return uint64(fr.dataSize / int64(fr.blockAlign))
+}
+
+// ReadFrames reads n sample frames starting at frame start into dst.
+// dst must provide at least n * BlockAlign bytes.
+//
+// The number of frames read is returned. If fewer than n frames are available io.EOF is returned.
+func (fr *FrameReader) ReadFrames(start, n uint64, dst []byte) (int, error) {
+	if uint64(len(dst)) < n*uint64(fr.blockAlign) {
+		msg := fmt.Sprintf("dst requires a minimum length of %d", n*uint64(fr.blockAlign))
+		return 0, errors.New(msg)
+	}
+
+	numFrames := fr.NumFrames()
+
+	if start >= numFrames {
+		return 0, io.EOF
+	}
+
+	count := n
+
+	if start+n > numFrames {
+		count = numFrames - start
+	}
+
+	offset := fr.dataPos + int64(start)*int64(fr.blockAlign)
+	size := int(count) * fr.blockAlign
+	read, err := fr.reader.ReadAt(dst[:size], offset)
+	frames := read / fr.blockAlign
+
+	if read == size {
+		err = nil
+	}
+
+	if err == nil && count < n {
+		err = io.EOF
+	}
+
+	return frames, err
+}
+
+// NewFrameReader creates a FrameReader for the sound data of provided container.
+// Format chunk 'fmt ' (RIFF) or common chunk 'COMM' (AIFF) is read to determine the frame layout.
+func NewFrameReader(reader io.ReaderAt, container *Container) (*FrameReader, error) {
+	if isAiff(container) {
+		return newAiffFrameReader(reader, container)
+	}
+
+	return newRiffFrameReader(reader, container)
+}
+
+func newRiffFrameReader(reader io.ReaderAt, container *Container) (*FrameReader, error) {
+	header, err := findHeader(container, FMTID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fmtData, err := ReadChunk(reader, header)
+
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := DecodePCMFormatChunk(fmtData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := findHeader(container, DATAID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fr := &FrameReader{reader: reader, format: format.Format(), channels: format.Channels(), bitsPerSample: format.BitsPerSample(), blockAlign: format.BlockAlign(), sampleRate: format.SamplesPerSec(), sampleOrder: binary.LittleEndian, unsigned8: true}
+
+	// WAVE_FORMAT_EXTENSIBLE carries the valid bits per sample and the format code in the first 2 bytes of the sub format GUID
+	if fr.format == waveFormatExtensible && len(fmtData) >= int(HeaderSizeBytes)+26 {
+		fr.validBits = int(binary.LittleEndian.Uint16(fmtData[HeaderSizeBytes+18 : HeaderSizeBytes+20]))
+		fr.format = int(binary.LittleEndian.Uint16(fmtData[HeaderSizeBytes+24 : HeaderSizeBytes+26]))
+	}
+
+	fr.dataPos = int64(data.StartPos() + HeaderSizeBytes)
+	fr.dataSize = int64(data.Size())
+
+	return fr, validateFrameReader(fr)
+}
+
+func newAiffFrameReader(reader io.ReaderAt, container *Container) (*FrameReader, error) {
+	comm, err := readCOMM(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ssnd, err := ReadSSND(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bytesPerSample := (comm.SampleSize() + 7) / 8
+	fr := &FrameReader{reader: reader, format: wavePCM, channels: comm.Channels(), bitsPerSample: comm.SampleSize(), validBits: comm.SampleSize(), blockAlign: comm.Channels() * bytesPerSample, sampleRate: int(comm.SampleRate()), sampleOrder: binary.BigEndian}
+
+	// AIFF-C compression types of uncompressed sample data
+	switch comm.CompressionType() {
+	case "", "NONE", "twos":
+	case "sowt":
+		fr.sampleOrder = binary.LittleEndian
+	case "fl32", "FL32", "fl64", "FL64":
+		fr.format = waveIEEEFloat
+	default:
+		fr.format = 0
+	}
+
+	fr.dataPos = int64(ssnd.DataPos())
+	fr.dataSize = int64(ssnd.DataSize())
+
+	// sample frames beyond those described in 'COMM' are ignored
+	if fr.blockAlign > 0 && fr.dataSize > int64(comm.SampleFrames())*int64(fr.blockAlign) {
+		fr.dataSize = int64(comm.SampleFrames()) * int64(fr.blockAlign)
+	}
+
+	return fr, validateFrameReader(fr)
+}
+
+func validateFrameReader(fr *FrameReader) error {
+	if fr.blockAlign <= 0 || fr.channels <= 0 {
+		msg := fmt.Sprintf("invalid frame layout: %d channels, block align %d", fr.channels, fr.blockAlign)
+		return errors.New(msg)
+	}
+
+	if fr.dataSize < 0 {
+		return errors.New("invalid sound data size")
+	}
+
+	return nil
+}
+
+func isAiff(container *Container) bool {
+	if container.Header == nil {
+		return false
+	}
+
+	format := container.Header.Format()
+
+	return format == "AIFF" || format == "AIFC"
+}
+
+// findHeader returns the first header with provided ID or an error if not found.
+func findHeader(container *Container, id string) (*Header, error) {
+	headers := container.FindHeaders(id)
+
+	if len(headers) == 0 {
+		msg := fmt.Sprintf("chunk '%s' not found", id)
+		return nil, errors.New(msg)
+	}
+
+	return headers[0], nil
+}
+
+func readCOMM(reader io.ReaderAt, container *Container) (*COMM, error) {
+	header, err := findHeader(container, COMMID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ReadChunk(reader, header)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeCOMMChunk(data)
+}
+
+// readFrameBlocks reads count frames starting at frame start in blocks and calls fn for each block.
+func readFrameBlocks(fr *FrameReader, start, count uint64, fn func(block []byte, frames int) error) error {
+	const blockFrames = 4096
+	block := make([]byte, blockFrames*fr.blockAlign)
+	end := start + count
+
+	if end > fr.NumFrames() {
+		end = fr.NumFrames()
+	}
+
+	for pos := start; pos < end; pos += blockFrames {
+		n := uint64(blockFrames)
+
+		if end-pos < n {
+			n = end - pos
+		}
+
+		frames, err := fr.ReadFrames(pos, n, block)
+
+		if err != nil {
+			return err
+		}
+
+		err = fn(block[:frames*fr.blockAlign], frames)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestNewFrameReader(t *testing.T) {
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), make([]byte, 40))
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	fr, err := NewFrameReader(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.Channels(), 2, "Channels")
+	assertEqual(t, fr.BitsPerSample(), 16, "BitsPerSample")
+	assertEqual(t, fr.BlockAlign(), 4, "BlockAlign")
+	assertEqual(t, fr.SampleRate(), 44100, "SampleRate")
+	assertEqual(t, fr.NumFrames(), uint64(10), "NumFrames")
+	assertEqual(t, fr.DataPos(), int64(ContainerHeaderSizeBytes+24+HeaderSizeBytes), "DataPos")
+
+	container.Headers = container.Headers[:1]
+	_, err = NewFrameReader(reader, container)
+
+	assertNotNil(t, err, "err without data chunk")
+
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 5, 24, 48000, FourCC{}, ""), 4, make([]byte, 15))
+	reader = bytes.NewReader(aiff)
+	container, _ = ReadAiff("test", reader)
+	fr, err = NewFrameReader(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.Channels(), 1, "Channels")
+	assertEqual(t, fr.BlockAlign(), 3, "BlockAlign")
+	assertEqual(t, fr.SampleRate(), 48000, "SampleRate")
+	assertEqual(t, fr.NumFrames(), uint64(5), "NumFrames")
+
+	// offset exceeding the chunk size
+	binary.BigEndian.PutUint32(aiff[container.FindHeaders(SSNDID)[0].StartPos()+HeaderSizeBytes:], 0xFFFFFFF0)
+	reader = bytes.NewReader(aiff)
+	container, _ = ReadAiff("test", reader)
+	fr, err = NewFrameReader(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.NumFrames(), uint64(0), "NumFrames")
+}
+
+func TestReadFrames(t *testing.T) {
+	data := make([]byte, 40)
+
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	fr, _ := NewFrameReader(reader, container)
+
+	dst := make([]byte, 8)
+	n, err := fr.ReadFrames(3, 2, dst)
+
+	assertNil(t, err, "err")
+	assertEqual(t, n, 2, "frames read")
+	assertEqual(t, bytes.Equal(dst, data[12:20]), true, "frames")
+
+	n, err = fr.ReadFrames(9, 2, dst)
+
+	assertEqual(t, err, io.EOF, "err")
+	assertEqual(t, n, 1, "frames read")
+	assertEqual(t, bytes.Equal(dst[:4], data[36:40]), true, "frames")
+
+	n, err = fr.ReadFrames(10, 1, dst)
+
+	assertEqual(t, err, io.EOF, "err")
+	assertEqual(t, n, 0, "frames read")
+
+	_, err = fr.ReadFrames(0, 3, dst)
+
+	assertNotNil(t, err, "err with short dst")
+
+	aiffData := make([]byte, 15)
+
+	for i := range aiffData {
+		aiffData[i] = byte(i)
+	}
+
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 5, 24, 48000, FourCC{}, ""), 4, aiffData)
+	reader = bytes.NewReader(aiff)
+	container, _ = ReadAiff("test", reader)
+	fr, _ = NewFrameReader(reader, container)
+	n, err = fr.ReadFrames(4, 1, dst)
+
+	assertNil(t, err, "err")
+	assertEqual(t, n, 1, "frames read")
+	assertEqual(t, bytes.Equal(dst[:3], aiffData[12:15]), true, "frames")
+}
+
+func TestReadFramesConcurrent(t *testing.T) {
+	data := make([]byte, 4000)
+
+	for i := range data {
+		data[i] = byte(i / 4)
+	}
+
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	fr, _ := NewFrameReader(reader, container)
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 100)
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func(frame uint64) {
+			defer wg.Done()
+			dst := make([]byte, 4)
+			_, err := fr.ReadFrames(frame, 1, dst)
+
+			if err != nil || dst[0] != byte(frame) || dst[3] != byte(frame) {
+				errs <- "unexpected frame"
+			}
+		}(uint64(i * 10))
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for msg := range errs {
+		t.Fatal(msg)
+	}
+}
+
+// createTestRiff creates a RIFF / WAVE byte stream containing provided format chunk and sound data.
+func createTestRiff(format *PCMFormat, data []byte) []byte {
+	chunks := format.Bytes()
+	chunks = append(chunks, EncodeChunkHeader(CreateFourCC(DATAID), uint32(len(data)), binary.LittleEndian).Bytes()...)
+	chunks = append(chunks, pad(data)...)
+	header := EncodeContainerHeader(CreateFourCC("RIFF"), uint32(len(chunks))+FormatSizeBytes, CreateFourCC("WAVE"), binary.LittleEndian)
+
+	return append(header.Bytes(), chunks...)
+}
+
+// createTestAiff creates an AIFF byte stream containing provided common chunk and sound data starting at offset.
+func createTestAiff(comm *COMM, offset uint32, data []byte) []byte {
+	chunks := comm.Bytes()
+	ssnd := make([]byte, 8+offset)
+	binary.BigEndian.PutUint32(ssnd[:4], offset)
+	ssnd = append(ssnd, data...)
+	chunks = append(chunks, EncodeChunkHeader(CreateFourCC(SSNDID), uint32(len(ssnd)), binary.BigEndian).Bytes()...)
+	chunks = append(chunks, pad(ssnd)...)
+	header := EncodeContainerHeader(CreateFourCC("FORM"), uint32(len(chunks))+FormatSizeBytes, CreateFourCC("AIFF"), binary.BigEndian)
+
+	return append(header.Bytes(), chunks...)
+}
@@ -0,0 +1,69 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// AIFCVersion1 is the timestamp of the AIFF-C format version 1, the only version defined.
+const AIFCVersion1 uint32 = 0xA2805140
+
+// FVER is AIFF-C format version chunk 'FVER' containing the timestamp of the format version.
+type FVER struct {
+	*Header
+	timestamp uint32
+}
+
+// Timestamp is the format version as seconds since 1904-01-01, usually AIFCVersion1.
+func (f *FVER) Timestamp() uint32 {
+	return f.timestamp
+}
+
+// SetTimestamp sets the format version timestamp.
+func (f *FVER) SetTimestamp(value uint32) {
+	f.timestamp = value
+}
+
+// String returns string represensation of chunk.
+func (f *FVER) String() string {
+	return fmt.Sprintf("Timestamp: %X", f.timestamp)
+}
+
+// Bytes converts FVER to byte array. A new Header with id 'FVER' is created.
+//
+// Header size is set to real data size. An amount of 12 bytes is returned.
+// chunk header - 8 bytes
+// timestamp - 4 bytes
+func (f *FVER) Bytes() []byte {
+	byteOrder := binary.BigEndian
+	data := make([]byte, 4)
+	byteOrder.PutUint32(data, f.timestamp)
+	header := EncodeChunkHeader(CreateFourCC(FVERID), uint32(len(data)), byteOrder)
+
+	return append(header.Bytes(), data...)
+}
+
+// EncodeFVERChunk returns encoded chunk 'FVER' containing provided timestamp.
+func EncodeFVERChunk(timestamp uint32) *FVER {
+	header := EncodeChunkHeader(CreateFourCC(FVERID), 4, binary.BigEndian)
+
+	return &FVER{Header: header, timestamp: timestamp}
+}
+
+// DecodeFVERChunk decodes provided byte array to FVER.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// timestamp - 4 bytes (min. requirement for successful decoding)
+func DecodeFVERChunk(data []byte) (*FVER, error) {
+	if len(data) < int(HeaderSizeBytes)+4 {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", int(HeaderSizeBytes)+4)
+		return nil, errors.New(msg)
+	}
+
+	byteOrder := binary.BigEndian
+	header := decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+
+	return &FVER{Header: header, timestamp: byteOrder.Uint32(data[HeaderSizeBytes : HeaderSizeBytes+4])}, nil
+}
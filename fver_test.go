@@ -0,0 +1,75 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFVER(t *testing.T) {
+	chunk := EncodeFVERChunk(AIFCVersion1)
+
+	assertEqual(t, chunk.ID(), FVERID, "ID")
+	assertEqual(t, chunk.Size(), uint32(4), "Size")
+
+	data := chunk.Bytes()
+
+	assertEqual(t, len(data), 12, "Bytes length")
+	assertEqual(t, binary.BigEndian.Uint32(data[8:12]), AIFCVersion1, "Timestamp big endian")
+
+	decoded, err := DecodeFVERChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Timestamp(), AIFCVersion1, "Timestamp")
+
+	_, err = DecodeFVERChunk(data[:11])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestAIFCWriterFVER(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "test.aifc"))
+
+	assertNil(t, err, "err")
+	defer file.Close()
+
+	w, _ := NewAiffWriter(file, CreateFourCC("AIFC"))
+	err = w.Encode(EncodeCOMMChunk(18, 1, 2, 16, 44100, FourCC{}, ""))
+
+	assertNil(t, err, "err")
+	assertNil(t, w.Close(), "err on Close")
+
+	file.Seek(0, io.SeekStart)
+	container, _ := ReadAiff(file.Name(), file)
+
+	assertEqual(t, len(container.Headers), 2, "headers length")
+	assertEqual(t, container.Headers[0].ID(), FVERID, "FVER written first")
+
+	out, _ := os.Create(filepath.Join(t.TempDir(), "copy.aifc"))
+	defer out.Close()
+
+	err = ReplaceChunks(out, file, container, EncodeAIFFTextChunk(NAMEID, "Name").Bytes())
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, io.SeekStart)
+	container, _ = ReadAiff(out.Name(), out)
+
+	assertEqual(t, len(container.FindHeaders(FVERID)), 1, "FVER not duplicated")
+	assertEqual(t, len(container.Headers), 3, "headers length")
+}
+
+func TestAIFFWriterWithoutFVER(t *testing.T) {
+	file, _ := os.Create(filepath.Join(t.TempDir(), "test.aiff"))
+	defer file.Close()
+
+	w, _ := NewAiffWriter(file, CreateFourCC("AIFF"))
+	w.Close()
+
+	file.Seek(0, io.SeekStart)
+	container, _ := ReadAiff(file.Name(), file)
+
+	assertEqual(t, len(container.Headers), 0, "headers length")
+}
@@ -0,0 +1,498 @@
+package chunk
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// id3HeaderSize is the byte size of the ID3v2 tag header.
+	id3HeaderSize = 10
+	// id3FrameHeaderSize is the byte size of an ID3v2.3 / ID3v2.4 frame header.
+	id3FrameHeaderSize = 10
+	// id3Unsynchronisation is the tag header flag of unsynchronisation.
+	id3Unsynchronisation = 0x80
+	// id3ExtendedHeader is the tag header flag of an extended header.
+	id3ExtendedHeader = 0x40
+)
+
+// ID3v2.3 frame format flags
+const (
+	id3v3Compression uint16 = 0x0080
+	id3v3Encryption  uint16 = 0x0040
+	id3v3Grouping    uint16 = 0x0020
+)
+
+// ID3v2.4 frame format flags
+const (
+	id3v4Grouping            uint16 = 0x0040
+	id3v4Compression         uint16 = 0x0008
+	id3v4Encryption          uint16 = 0x0004
+	id3v4Unsynchronisation   uint16 = 0x0002
+	id3v4DataLengthIndicator uint16 = 0x0001
+)
+
+// ID3Frame is a frame of an ID3v2 tag. Data is the frame content with unsynchronisation, data length
+// indicator and compression removed. Data of encrypted frames is preserved as read.
+type ID3Frame struct {
+	// ID is the 4 character frame id, e.g. 'TIT2'.
+	ID string
+	// Flags are the status and format flags of the frame header of the tag version.
+	Flags uint16
+	// Data is the frame content.
+	Data []byte
+}
+
+// String returns string represensation of frame.
+func (f *ID3Frame) String() string {
+	return fmt.Sprintf("ID: %s Flags: %016b Size: %d", f.ID, f.Flags, len(f.Data))
+}
+
+// ID3 is chunk 'id3 ' (RIFF) or 'ID3 ' (AIFF and RIFF) containing an ID3v2.3 or ID3v2.4 tag.
+type ID3 struct {
+	*Header
+	version           uint8
+	revision          uint8
+	unsynchronisation bool
+	// Frames are the frames of the tag in order.
+	Frames []*ID3Frame
+}
+
+// Version is the major version of the tag, 3 or 4.
+func (t *ID3) Version() int {
+	return int(t.version)
+}
+
+// Revision is the revision of the tag.
+func (t *ID3) Revision() int {
+	return int(t.revision)
+}
+
+// Unsynchronisation returns true if unsynchronisation is applied to the tag.
+func (t *ID3) Unsynchronisation() bool {
+	return t.unsynchronisation
+}
+
+// SetUnsynchronisation sets if unsynchronisation is applied on encoding, to the whole tag in version 3
+// and to each frame in version 4.
+func (t *ID3) SetUnsynchronisation(value bool) {
+	t.unsynchronisation = value
+}
+
+// Frame returns the first frame of provided id, nil if not found.
+func (t *ID3) Frame(id string) *ID3Frame {
+	for _, f := range t.Frames {
+		if f.ID == id {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// FindFrames returns all frames of provided id.
+func (t *ID3) FindFrames(id string) []*ID3Frame {
+	var frames []*ID3Frame
+
+	for _, f := range t.Frames {
+		if f.ID == id {
+			frames = append(frames, f)
+		}
+	}
+
+	return frames
+}
+
+// RemoveFrames removes all frames of provided id.
+func (t *ID3) RemoveFrames(id string) {
+	var frames []*ID3Frame
+
+	for _, f := range t.Frames {
+		if f.ID != id {
+			frames = append(frames, f)
+		}
+	}
+
+	t.Frames = frames
+}
+
+// String returns string represensation of chunk.
+func (t *ID3) String() string {
+	lines := make([]string, len(t.Frames))
+
+	for i, f := range t.Frames {
+		lines[i] = f.String()
+	}
+
+	return fmt.Sprintf("Version: 2.%d.%d\nUnsynchronisation: %t\nFrames: %d\n%s", t.version, t.revision, t.unsynchronisation, len(t.Frames), strings.Join(lines, "\n"))
+}
+
+// Bytes converts ID3 to byte array. A new Header with the id and byte order of the chunk is created.
+//
+// Header size is set to real data size. A minimum amount of 18 bytes is returned.
+// chunk header - 8 bytes
+// tag header - 10 bytes: 'ID3', version, revision, flags, syncsafe size
+// frames - 10 bytes frame header and frame content each
+//
+// An extended header is not written. A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (t *ID3) Bytes() []byte {
+	data := t.encodeFrames(t.Frames)
+	var flags byte
+
+	if t.unsynchronisation {
+		flags |= id3Unsynchronisation
+
+		if t.version == 3 {
+			data = unsynchronise(data)
+		}
+	}
+
+	tag := []byte{'I', 'D', '3', t.version, t.revision, flags, 0, 0, 0, 0}
+	putSyncsafe(tag[6:10], uint32(len(data)))
+	data = append(tag, data...)
+	header := EncodeChunkHeader(CreateFourCC(t.ID()), uint32(len(data)), t.byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// encodeFrames encodes provided frames for the tag version.
+func (t *ID3) encodeFrames(frames []*ID3Frame) []byte {
+	var data []byte
+
+	for _, f := range frames {
+		content := f.Data
+		flags := f.Flags
+		id := CreateFourCC(f.ID)
+		header := make([]byte, id3FrameHeaderSize)
+		copy(header[:4], id[:])
+
+		if t.version == 4 {
+			if t.unsynchronisation {
+				content = unsynchronise(content)
+				flags |= id3v4Unsynchronisation
+			}
+
+			putSyncsafe(header[4:8], uint32(len(content)))
+		} else {
+			binary.BigEndian.PutUint32(header[4:8], uint32(len(content)))
+		}
+
+		binary.BigEndian.PutUint16(header[8:10], flags)
+		data = append(data, header...)
+		data = append(data, content...)
+	}
+
+	return data
+}
+
+// EncodeID3Chunk returns an empty chunk containing an ID3v2 tag of provided version (3 or 4).
+// Use id 'id3 ' and little endian byte order for RIFF and id 'ID3 ' and big endian byte order for AIFF.
+func EncodeID3Chunk(id string, byteOrder binary.ByteOrder, version int) (*ID3, error) {
+	if version != 3 && version != 4 {
+		msg := fmt.Sprintf("unsupported ID3v2 version %d", version)
+		return nil, errors.New(msg)
+	}
+
+	t := &ID3{Header: EncodeChunkHeader(CreateFourCC(id), id3HeaderSize, byteOrder), version: uint8(version)}
+
+	return t, nil
+}
+
+// DecodeID3Chunk decodes provided byte array to ID3. The byte order of the chunk size is detected
+// from the tag size, chunk 'id3 ' is always little endian.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// tag header - 10 bytes (min. requirement for successful decoding)
+// extended header - skipped if present
+// frames - not restricted amount of bytes
+func DecodeID3Chunk(data []byte) (*ID3, error) {
+	if len(data) < int(HeaderSizeBytes)+id3HeaderSize {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", int(HeaderSizeBytes)+id3HeaderSize)
+		return nil, errors.New(msg)
+	}
+
+	tag := data[HeaderSizeBytes:]
+
+	if string(tag[:3]) != "ID3" {
+		return nil, errors.New("chunk does not contain an ID3v2 tag")
+	}
+
+	t := &ID3{version: tag[3], revision: tag[4]}
+	t.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, id3ByteOrder(data))
+
+	if t.version != 3 && t.version != 4 {
+		msg := fmt.Sprintf("unsupported ID3v2 version %d", t.version)
+		return t, errors.New(msg)
+	}
+
+	flags := tag[5]
+	size := int(syncsafe(tag[6:10]))
+	body := tag[id3HeaderSize:]
+
+	if size < len(body) {
+		body = body[:size]
+	}
+
+	t.unsynchronisation = flags&id3Unsynchronisation != 0
+
+	if t.version == 3 && t.unsynchronisation {
+		body = resynchronise(body)
+	}
+
+	if flags&id3ExtendedHeader != 0 && len(body) >= 4 {
+		extSize := int(binary.BigEndian.Uint32(body[:4])) + 4
+
+		if t.version == 4 {
+			extSize = int(syncsafe(body[:4]))
+		}
+
+		if extSize > len(body) {
+			extSize = len(body)
+		}
+
+		body = body[extSize:]
+	}
+
+	frames, err := t.decodeFrames(body)
+	t.Frames = frames
+
+	return t, err
+}
+
+// decodeFrames decodes the frames of provided data until data or padding is reached.
+func (t *ID3) decodeFrames(data []byte) ([]*ID3Frame, error) {
+	var frames []*ID3Frame
+	pos := 0
+
+	for pos+id3FrameHeaderSize <= len(data) && data[pos] != 0 {
+		f := &ID3Frame{ID: string(data[pos : pos+4]), Flags: binary.BigEndian.Uint16(data[pos+8 : pos+10])}
+		size := int(binary.BigEndian.Uint32(data[pos+4 : pos+8]))
+
+		if t.version == 4 {
+			size = int(syncsafe(data[pos+4 : pos+8]))
+		}
+
+		start := pos + id3FrameHeaderSize
+
+		if size > len(data)-start {
+			msg := fmt.Sprintf("frame '%s' exceeds tag size", f.ID)
+			return frames, errors.New(msg)
+		}
+
+		content, err := t.decodeFrameContent(f, data[start:start+size])
+
+		if err != nil {
+			return frames, err
+		}
+
+		f.Data = content
+		frames = append(frames, f)
+		pos = start + size
+	}
+
+	return frames, nil
+}
+
+// decodeFrameContent removes unsynchronisation, data length indicator and compression of provided frame content
+// and clears the according flags.
+func (t *ID3) decodeFrameContent(f *ID3Frame, data []byte) ([]byte, error) {
+	var prefix []byte
+	pos := 0
+	compression, encryption, grouping := id3v3Compression, id3v3Encryption, id3v3Grouping
+
+	if t.version == 4 {
+		compression, encryption, grouping = id3v4Compression, id3v4Encryption, id3v4Grouping
+
+		if f.Flags&id3v4Unsynchronisation != 0 || t.unsynchronisation {
+			data = resynchronise(data)
+			f.Flags &^= id3v4Unsynchronisation
+		}
+	}
+
+	if f.Flags&encryption != 0 {
+		return append([]byte{}, data...), nil
+	}
+
+	if t.version == 3 && f.Flags&compression != 0 {
+		pos += 4
+	}
+
+	if f.Flags&grouping != 0 && pos < len(data) {
+		prefix = data[pos : pos+1]
+		pos++
+	}
+
+	if t.version == 4 && f.Flags&id3v4DataLengthIndicator != 0 {
+		pos += 4
+		f.Flags &^= id3v4DataLengthIndicator
+	}
+
+	if pos > len(data) {
+		msg := fmt.Sprintf("frame '%s' is too short", f.ID)
+		return nil, errors.New(msg)
+	}
+
+	content := append(append([]byte{}, prefix...), data[pos:]...)
+
+	if f.Flags&compression == 0 {
+		return content, nil
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(data[pos:]))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+	inflated, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f.Flags &^= compression
+
+	return append(append([]byte{}, prefix...), inflated...), nil
+}
+
+// content returns the frame content without group id, nil if the frame is encrypted.
+func (t *ID3) content(f *ID3Frame) []byte {
+	encryption, grouping := id3v3Encryption, id3v3Grouping
+
+	if t.version == 4 {
+		encryption, grouping = id3v4Encryption, id3v4Grouping
+	}
+
+	if f.Flags&encryption != 0 {
+		return nil
+	}
+
+	if f.Flags&grouping != 0 && len(f.Data) > 0 {
+		return f.Data[1:]
+	}
+
+	return f.Data
+}
+
+// id3ByteOrder returns the byte order of the chunk size closest to the tag size.
+func id3ByteOrder(data []byte) binary.ByteOrder {
+	if string(data[:IDSizeBytes]) == ID3RIFFID {
+		return binary.LittleEndian
+	}
+
+	tagSize := int64(syncsafe(data[HeaderSizeBytes+6:HeaderSizeBytes+10])) + id3HeaderSize
+	le := int64(binary.LittleEndian.Uint32(data[IDSizeBytes:HeaderSizeBytes])) - tagSize
+	be := int64(binary.BigEndian.Uint32(data[IDSizeBytes:HeaderSizeBytes])) - tagSize
+
+	if le < 0 {
+		le = -le
+	}
+
+	if be < 0 {
+		be = -be
+	}
+
+	if le < be {
+		return binary.LittleEndian
+	}
+
+	return binary.BigEndian
+}
+
+// syncsafe decodes a 4 byte syncsafe integer using 7 bits of each byte.
+func syncsafe(data []byte) uint32 {
+	return uint32(data[0]&0x7F)<<21 | uint32(data[1]&0x7F)<<14 | uint32(data[2]&0x7F)<<7 | uint32(data[3]&0x7F)
+}
+
+// putSyncsafe encodes value as 4 byte syncsafe integer.
+func putSyncsafe(data []byte, value uint32) {
+	data[0] = byte(value >> 21 & 0x7F)
+	data[1] = byte(value >> 14 & 0x7F)
+	data[2] = byte(value >> 7 & 0x7F)
+	data[3] = byte(value & 0x7F)
+}
+
+// unsynchronise inserts a zero byte after each 0xFF followed by a byte >= 0xE0 or 0x00 and after a final 0xFF.
+func unsynchronise(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+
+	for i, b := range data {
+		out = append(out, b)
+
+		if b == 0xFF && (i == len(data)-1 || data[i+1] >= 0xE0 || data[i+1] == 0) {
+			out = append(out, 0)
+		}
+	}
+
+	return out
+}
+
+// resynchronise removes the zero bytes following 0xFF.
+func resynchronise(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+
+	for i := 0; i < len(data); i++ {
+		out = append(out, data[i])
+
+		if data[i] == 0xFF && i+1 < len(data) && data[i+1] == 0 {
+			i++
+		}
+	}
+
+	return out
+}
+
+// ReadID3 returns the ID3v2 tag of provided container, nil if the container has no chunk 'id3 ' or 'ID3 '.
+func ReadID3(reader io.ReaderAt, container *Container) (*ID3, error) {
+	header := findID3(container)
+
+	if header == nil {
+		return nil, nil
+	}
+
+	data, err := ReadChunk(reader, header)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeID3Chunk(data)
+}
+
+// WriteID3 copies provided container to ws with the ID3v2 tag replaced by provided tag. The chunk id of
+// an existing tag is kept, else 'id3 ' is used for RIFF and 'ID3 ' for AIFF.
+func WriteID3(ws io.WriteSeeker, reader io.ReaderAt, container *Container, tag *ID3) error {
+	id, byteOrder := ID3RIFFID, binary.ByteOrder(binary.LittleEndian)
+
+	if isAiff(container) {
+		id, byteOrder = ID3ID, binary.BigEndian
+	}
+
+	if header := findID3(container); header != nil {
+		id = header.ID()
+	}
+
+	t := *tag
+	t.Header = EncodeChunkHeader(CreateFourCC(id), tag.Size(), byteOrder)
+
+	return ReplaceChunks(ws, reader, container, t.Bytes())
+}
+
+func findID3(container *Container) *Header {
+	for _, header := range container.Headers {
+		if header.ID() == ID3RIFFID || header.ID() == ID3ID {
+			return header
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package chunk
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncodeID3Chunk(t *testing.T) {
+	chunk, err := EncodeID3Chunk(ID3RIFFID, binary.LittleEndian, 4)
+
+	assertNil(t, err, "err")
+	assertEqual(t, chunk.ID(), ID3RIFFID, "ID")
+	assertEqual(t, chunk.Version(), 4, "Version")
+	assertEqual(t, len(chunk.Bytes()), 18, "Bytes length")
+
+	_, err = EncodeID3Chunk(ID3ID, binary.BigEndian, 2)
+
+	assertNotNil(t, err, "err with unsupported version")
+}
+
+func TestDecodeID3Chunk(t *testing.T) {
+	for _, version := range []int{3, 4} {
+		for _, unsync := range []bool{false, true} {
+			chunk, _ := EncodeID3Chunk(ID3ID, binary.BigEndian, version)
+			chunk.SetUnsynchronisation(unsync)
+			chunk.SetText(ID3Title, "Title")
+			chunk.Frames = append(chunk.Frames, &ID3Frame{ID: "XBIN", Data: []byte{0xFF, 0xE0, 0xFF, 0x00, 0xFF}})
+			data := chunk.Bytes()
+
+			assertEqual(t, (binary.BigEndian.Uint32(data[4:8])+9)&^1, uint32(len(data)), "Size big endian")
+
+			decoded, err := DecodeID3Chunk(data)
+
+			assertNil(t, err, "err")
+			assertEqual(t, decoded.Version(), version, "Version")
+			assertEqual(t, decoded.Unsynchronisation(), unsync, "Unsynchronisation")
+			assertEqual(t, decoded.Text(ID3Title), "Title", "Text")
+			assertEqual(t, bytes.Equal(decoded.Frame("XBIN").Data, []byte{0xFF, 0xE0, 0xFF, 0x00, 0xFF}), true, "binary frame data")
+			assertEqual(t, decoded.Frame("XBIN").Flags, uint16(0), "Flags")
+
+			if unsync {
+				assertEqual(t, bytes.Contains(data, []byte{0xFF, 0xE0}), false, "unsynchronised data")
+			}
+		}
+	}
+
+	_, err := DecodeID3Chunk(make([]byte, 17))
+
+	assertNotNil(t, err, "err with short data")
+
+	_, err = DecodeID3Chunk(make([]byte, 18))
+
+	assertNotNil(t, err, "err without tag")
+}
+
+func TestDecodeID3ChunkByteOrder(t *testing.T) {
+	chunk, _ := EncodeID3Chunk(ID3ID, binary.LittleEndian, 3)
+	chunk.SetText(ID3Artist, "Artist")
+	decoded, err := DecodeID3Chunk(chunk.Bytes())
+
+	assertNil(t, err, "err")
+	assertEqual(t, bytes.Equal(decoded.Bytes(), chunk.Bytes()), true, "Bytes little endian")
+	assertEqual(t, decoded.Text(ID3Artist), "Artist", "Text")
+}
+
+func TestDecodeID3ChunkExtendedHeader(t *testing.T) {
+	frame := append([]byte("TIT2"), 0, 0, 0, 3, 0, 0, 0, 'a', 'b')
+
+	v3 := []byte{'I', 'D', '3', 3, 0, id3ExtendedHeader, 0, 0, 0, 0, 0, 0, 0, 6, 0, 0, 0, 0, 0, 0}
+	v3 = append(v3, frame...)
+	putSyncsafe(v3[6:10], uint32(len(v3)-10))
+
+	v4 := []byte{'I', 'D', '3', 4, 0, id3ExtendedHeader, 0, 0, 0, 0, 0, 0, 0, 6, 1, 0}
+	v4 = append(v4, frame...)
+	putSyncsafe(v4[6:10], uint32(len(v4)-10))
+
+	for _, tag := range [][]byte{v3, v4} {
+		data := append(EncodeChunkHeader(CreateFourCC(ID3ID), uint32(len(tag)), binary.BigEndian).Bytes(), tag...)
+		decoded, err := DecodeID3Chunk(data)
+
+		assertNil(t, err, "err")
+		assertEqual(t, len(decoded.Frames), 1, "Frames length")
+		assertEqual(t, decoded.Text(ID3Title), "ab", "Text")
+	}
+}
+
+func TestDecodeID3ChunkCompression(t *testing.T) {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	w.Write([]byte{ID3Latin1, 'T', 'i', 't', 'l', 'e'})
+	w.Close()
+
+	v3Content := append([]byte{0, 0, 0, 6}, compressed.Bytes()...)
+	v3 := []byte{'I', 'D', '3', 3, 0, 0, 0, 0, 0, 0, 'T', 'I', 'T', '2', 0, 0, 0, byte(len(v3Content)), 0, byte(id3v3Compression)}
+	v3 = append(v3, v3Content...)
+	putSyncsafe(v3[6:10], uint32(len(v3)-10))
+
+	v4Content := append([]byte{0, 0, 0, 6}, compressed.Bytes()...)
+	v4 := []byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, 0, 'T', 'I', 'T', '2', 0, 0, 0, byte(len(v4Content)), 0, byte(id3v4Compression | id3v4DataLengthIndicator)}
+	v4 = append(v4, v4Content...)
+	putSyncsafe(v4[6:10], uint32(len(v4)-10))
+
+	for _, tag := range [][]byte{v3, v4} {
+		data := append(EncodeChunkHeader(CreateFourCC(ID3RIFFID), uint32(len(tag)), binary.LittleEndian).Bytes(), tag...)
+		decoded, err := DecodeID3Chunk(data)
+
+		assertNil(t, err, "err")
+		assertEqual(t, decoded.Text(ID3Title), "Title", "Text")
+		assertEqual(t, decoded.Frames[0].Flags, uint16(0), "Flags")
+	}
+}
+
+func TestReadWriteID3(t *testing.T) {
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), make([]byte, 4))
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 2, 16, 44100, FourCC{}, ""), 0, make([]byte, 4))
+
+	for _, file := range []struct {
+		data []byte
+		id   string
+		read func(name string, reader io.ReadSeeker) (*Container, error)
+	}{{riff, ID3RIFFID, ReadRiff}, {aiff, ID3ID, ReadAiff}} {
+		reader := bytes.NewReader(file.data)
+		container, _ := file.read("test", reader)
+		tag, err := ReadID3(reader, container)
+
+		assertNil(t, err, "err")
+		assertEqual(t, tag, (*ID3)(nil), "ID3 not present")
+
+		tag, _ = EncodeID3Chunk(ID3ID, binary.BigEndian, 4)
+		tag.SetText(ID3Title, "Title")
+		out, err := os.Create(filepath.Join(t.TempDir(), "out"))
+
+		assertNil(t, err, "err")
+		defer out.Close()
+
+		err = WriteID3(out, reader, container, tag)
+
+		assertNil(t, err, "err")
+
+		out.Seek(0, io.SeekStart)
+		container, _ = file.read(out.Name(), out)
+		header := findID3(container)
+
+		assertEqual(t, header.ID(), file.id, "ID")
+
+		tag, err = ReadID3(out, container)
+
+		assertNil(t, err, "err")
+		assertEqual(t, tag.Text(ID3Title), "Title", "Text")
+	}
+}
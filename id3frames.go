@@ -0,0 +1,577 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// ID3 frame IDs
+const (
+	// ID3Album TALB
+	ID3Album = "TALB"
+	// ID3Artist TPE1
+	ID3Artist = "TPE1"
+	// ID3AlbumArtist TPE2
+	ID3AlbumArtist = "TPE2"
+	// ID3Chapter CHAP
+	ID3Chapter = "CHAP"
+	// ID3Comment COMM
+	ID3Comment = "COMM"
+	// ID3Composer TCOM
+	ID3Composer = "TCOM"
+	// ID3Genre TCON
+	ID3Genre = "TCON"
+	// ID3ISRC TSRC
+	ID3ISRC = "TSRC"
+	// ID3Picture APIC
+	ID3Picture = "APIC"
+	// ID3Private PRIV
+	ID3Private = "PRIV"
+	// ID3Publisher TPUB
+	ID3Publisher = "TPUB"
+	// ID3RecordingTime TDRC (ID3v2.4)
+	ID3RecordingTime = "TDRC"
+	// ID3Title TIT2
+	ID3Title = "TIT2"
+	// ID3Track TRCK
+	ID3Track = "TRCK"
+	// ID3UserText TXXX
+	ID3UserText = "TXXX"
+	// ID3Year TYER (ID3v2.3)
+	ID3Year = "TYER"
+)
+
+// ID3 text encodings
+const (
+	// ID3Latin1 is ISO-8859-1 text encoding.
+	ID3Latin1 byte = 0
+	// ID3UTF16 is UTF-16 text encoding with byte order mark.
+	ID3UTF16 byte = 1
+	// ID3UTF16BE is UTF-16 big endian text encoding without byte order mark (ID3v2.4).
+	ID3UTF16BE byte = 2
+	// ID3UTF8 is UTF-8 text encoding (ID3v2.4).
+	ID3UTF8 byte = 3
+)
+
+// ID3CommentFrame is frame 'COMM' containing a comment.
+type ID3CommentFrame struct {
+	// Language is the ISO-639-2 language code, e.g. 'eng'.
+	Language    string
+	Description string
+	Text        string
+}
+
+// ID3PictureFrame is frame 'APIC' containing an attached picture.
+type ID3PictureFrame struct {
+	MIMEType string
+	// PictureType is the type of the picture, e.g. 3 for the front cover.
+	PictureType byte
+	Description string
+	Data        []byte
+}
+
+// ID3UserTextFrame is frame 'TXXX' containing a user defined text.
+type ID3UserTextFrame struct {
+	Description string
+	Value       string
+}
+
+// ID3PrivateFrame is frame 'PRIV' containing private data of an owner.
+type ID3PrivateFrame struct {
+	// Owner identifies the owner of the data, usually an URL or email address.
+	Owner string
+	Data  []byte
+}
+
+// ID3ChapterFrame is frame 'CHAP' marking a chapter of the audio (ID3v2 Chapter Frame Addendum).
+type ID3ChapterFrame struct {
+	// ElementID is the unique id of the chapter.
+	ElementID string
+	// StartTime is the start of the chapter in milliseconds.
+	StartTime uint32
+	// EndTime is the end of the chapter in milliseconds.
+	EndTime uint32
+	// StartOffset is the byte offset of the start in the audio, 0xFFFFFFFF if not used.
+	StartOffset uint32
+	// EndOffset is the byte offset of the end in the audio, 0xFFFFFFFF if not used.
+	EndOffset uint32
+	// Frames are embedded frames, e.g. 'TIT2' with the chapter title.
+	Frames []*ID3Frame
+}
+
+// Text returns the value of text frame of provided id, multiple values are separated by '/'.
+// An empty string is returned if not found.
+func (t *ID3) Text(id string) string {
+	return strings.Join(t.TextValues(id), "/")
+}
+
+// TextValues returns the values of text frame of provided id.
+func (t *ID3) TextValues(id string) []string {
+	f := t.Frame(id)
+
+	if f == nil {
+		return nil
+	}
+
+	data := t.content(f)
+
+	if len(data) < 1 {
+		return nil
+	}
+
+	values := splitID3Text(data[0], data[1:])
+
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+
+	return values
+}
+
+// SetText sets text frame of provided id to provided values. Multiple values are separated by null in
+// version 4 and by '/' in version 3. No values remove the frame.
+func (t *ID3) SetText(id string, values ...string) {
+	if len(values) == 0 {
+		t.RemoveFrames(id)
+		return
+	}
+
+	if t.version == 3 {
+		values = []string{strings.Join(values, "/")}
+	}
+
+	enc := t.textEncoding(values...)
+	data := []byte{enc}
+
+	for i, v := range values {
+		if i > 0 {
+			data = append(data, id3Terminator(enc)...)
+		}
+
+		data = append(data, encodeID3Text(enc, v)...)
+	}
+
+	t.setFrame(&ID3Frame{ID: id, Data: data}, func(f *ID3Frame) bool { return f.ID == id })
+}
+
+// Comments returns the comments of frames 'COMM'.
+func (t *ID3) Comments() []*ID3CommentFrame {
+	var comments []*ID3CommentFrame
+
+	for _, f := range t.FindFrames(ID3Comment) {
+		if c := t.decodeComment(f); c != nil {
+			comments = append(comments, c)
+		}
+	}
+
+	return comments
+}
+
+// SetComment adds provided comment, replacing a comment with the same language and description.
+func (t *ID3) SetComment(comment *ID3CommentFrame) {
+	enc := t.textEncoding(comment.Description, comment.Text)
+	language := []byte(terminate(comment.Language, 3))
+	data := append([]byte{enc}, append(language, make([]byte, 3-len(language))...)...)
+	data = append(data, encodeID3Text(enc, comment.Description)...)
+	data = append(data, id3Terminator(enc)...)
+	data = append(data, encodeID3Text(enc, comment.Text)...)
+
+	t.setFrame(&ID3Frame{ID: ID3Comment, Data: data}, func(f *ID3Frame) bool {
+		c := t.decodeComment(f)
+
+		return c != nil && c.Language == comment.Language && c.Description == comment.Description
+	})
+}
+
+func (t *ID3) decodeComment(f *ID3Frame) *ID3CommentFrame {
+	if f.ID != ID3Comment {
+		return nil
+	}
+
+	data := t.content(f)
+
+	if len(data) < 4 {
+		return nil
+	}
+
+	values := splitID3Text(data[0], data[4:])
+	c := &ID3CommentFrame{Language: nullTermToString(data[1:4])}
+
+	if len(values) > 0 {
+		c.Description = values[0]
+	}
+
+	if len(values) > 1 {
+		c.Text = values[1]
+	}
+
+	return c
+}
+
+// Pictures returns the attached pictures of frames 'APIC'.
+func (t *ID3) Pictures() []*ID3PictureFrame {
+	var pictures []*ID3PictureFrame
+
+	for _, f := range t.FindFrames(ID3Picture) {
+		if p := t.decodePicture(f); p != nil {
+			pictures = append(pictures, p)
+		}
+	}
+
+	return pictures
+}
+
+// SetPicture adds provided attached picture, replacing a picture with the same picture type and description.
+func (t *ID3) SetPicture(picture *ID3PictureFrame) {
+	enc := t.textEncoding(picture.Description)
+	data := append([]byte{enc}, encodeID3Text(ID3Latin1, picture.MIMEType)...)
+	data = append(data, 0, picture.PictureType)
+	data = append(data, encodeID3Text(enc, picture.Description)...)
+	data = append(data, id3Terminator(enc)...)
+	data = append(data, picture.Data...)
+
+	t.setFrame(&ID3Frame{ID: ID3Picture, Data: data}, func(f *ID3Frame) bool {
+		p := t.decodePicture(f)
+
+		return p != nil && p.PictureType == picture.PictureType && p.Description == picture.Description
+	})
+}
+
+func (t *ID3) decodePicture(f *ID3Frame) *ID3PictureFrame {
+	if f.ID != ID3Picture {
+		return nil
+	}
+
+	data := t.content(f)
+
+	if len(data) < 1 {
+		return nil
+	}
+
+	i := bytes.IndexByte(data[1:], 0)
+
+	if i < 0 || 2+i >= len(data) {
+		return nil
+	}
+
+	p := &ID3PictureFrame{MIMEType: decodeID3Text(ID3Latin1, data[1:1+i]), PictureType: data[2+i]}
+	description, n := readID3Text(data[0], data[3+i:])
+	p.Description = description
+	p.Data = data[3+i+n:]
+
+	return p
+}
+
+// UserTexts returns the user defined texts of frames 'TXXX'.
+func (t *ID3) UserTexts() []*ID3UserTextFrame {
+	var texts []*ID3UserTextFrame
+
+	for _, f := range t.FindFrames(ID3UserText) {
+		if u := t.decodeUserText(f); u != nil {
+			texts = append(texts, u)
+		}
+	}
+
+	return texts
+}
+
+// UserText returns the value of the user defined text of provided description, an empty string if not found.
+func (t *ID3) UserText(description string) string {
+	for _, u := range t.UserTexts() {
+		if u.Description == description {
+			return u.Value
+		}
+	}
+
+	return ""
+}
+
+// SetUserText sets the user defined text of provided description.
+func (t *ID3) SetUserText(description string, value string) {
+	enc := t.textEncoding(description, value)
+	data := append([]byte{enc}, encodeID3Text(enc, description)...)
+	data = append(data, id3Terminator(enc)...)
+	data = append(data, encodeID3Text(enc, value)...)
+
+	t.setFrame(&ID3Frame{ID: ID3UserText, Data: data}, func(f *ID3Frame) bool {
+		u := t.decodeUserText(f)
+
+		return u != nil && u.Description == description
+	})
+}
+
+func (t *ID3) decodeUserText(f *ID3Frame) *ID3UserTextFrame {
+	if f.ID != ID3UserText {
+		return nil
+	}
+
+	data := t.content(f)
+
+	if len(data) < 1 {
+		return nil
+	}
+
+	values := splitID3Text(data[0], data[1:])
+	u := &ID3UserTextFrame{}
+
+	if len(values) > 0 {
+		u.Description = values[0]
+	}
+
+	if len(values) > 1 {
+		u.Value = values[1]
+	}
+
+	return u
+}
+
+// Privates returns the private data of frames 'PRIV'.
+func (t *ID3) Privates() []*ID3PrivateFrame {
+	var privates []*ID3PrivateFrame
+
+	for _, f := range t.FindFrames(ID3Private) {
+		data := t.content(f)
+		i := bytes.IndexByte(data, 0)
+
+		if i < 0 {
+			continue
+		}
+
+		privates = append(privates, &ID3PrivateFrame{Owner: decodeID3Text(ID3Latin1, data[:i]), Data: data[i+1:]})
+	}
+
+	return privates
+}
+
+// AddPrivate adds provided private data.
+func (t *ID3) AddPrivate(private *ID3PrivateFrame) {
+	data := append(encodeID3Text(ID3Latin1, private.Owner), 0)
+	data = append(data, private.Data...)
+	t.Frames = append(t.Frames, &ID3Frame{ID: ID3Private, Data: data})
+}
+
+// Chapters returns the chapters of frames 'CHAP'.
+func (t *ID3) Chapters() []*ID3ChapterFrame {
+	var chapters []*ID3ChapterFrame
+
+	for _, f := range t.FindFrames(ID3Chapter) {
+		if c := t.decodeChapter(f); c != nil {
+			chapters = append(chapters, c)
+		}
+	}
+
+	return chapters
+}
+
+// SetChapter sets provided chapter, replacing a chapter with the same element id.
+func (t *ID3) SetChapter(chapter *ID3ChapterFrame) {
+	data := append(encodeID3Text(ID3Latin1, chapter.ElementID), 0)
+	times := make([]byte, 16)
+	binary.BigEndian.PutUint32(times[0:4], chapter.StartTime)
+	binary.BigEndian.PutUint32(times[4:8], chapter.EndTime)
+	binary.BigEndian.PutUint32(times[8:12], chapter.StartOffset)
+	binary.BigEndian.PutUint32(times[12:16], chapter.EndOffset)
+	data = append(data, times...)
+	data = append(data, t.embedded().encodeFrames(chapter.Frames)...)
+
+	t.setFrame(&ID3Frame{ID: ID3Chapter, Data: data}, func(f *ID3Frame) bool {
+		c := t.decodeChapter(f)
+
+		return c != nil && c.ElementID == chapter.ElementID
+	})
+}
+
+func (t *ID3) decodeChapter(f *ID3Frame) *ID3ChapterFrame {
+	if f.ID != ID3Chapter {
+		return nil
+	}
+
+	data := t.content(f)
+	i := bytes.IndexByte(data, 0)
+
+	if i < 0 || len(data) < i+17 {
+		return nil
+	}
+
+	c := &ID3ChapterFrame{ElementID: decodeID3Text(ID3Latin1, data[:i])}
+	times := data[i+1 : i+17]
+	c.StartTime = binary.BigEndian.Uint32(times[0:4])
+	c.EndTime = binary.BigEndian.Uint32(times[4:8])
+	c.StartOffset = binary.BigEndian.Uint32(times[8:12])
+	c.EndOffset = binary.BigEndian.Uint32(times[12:16])
+	frames, err := t.embedded().decodeFrames(data[i+17:])
+
+	if err != nil {
+		return nil
+	}
+
+	c.Frames = frames
+
+	return c
+}
+
+// embedded returns a tag of the same version without unsynchronisation to encode and decode embedded frames,
+// which are unsynchronised as part of the containing frame.
+func (t *ID3) embedded() *ID3 {
+	return &ID3{version: t.version}
+}
+
+// Title returns the text of embedded frame 'TIT2' of the chapter.
+func (c *ID3ChapterFrame) Title() string {
+	t := &ID3{version: 4, Frames: c.Frames}
+
+	return t.Text(ID3Title)
+}
+
+// String returns string represensation of chapter.
+func (c *ID3ChapterFrame) String() string {
+	return fmt.Sprintf("Element ID: %s Start time: %d End time: %d Title: %s", c.ElementID, c.StartTime, c.EndTime, c.Title())
+}
+
+// setFrame replaces the first frame matching provided function by provided frame and removes further
+// matching frames. The frame is appended if no frame matches.
+func (t *ID3) setFrame(frame *ID3Frame, match func(f *ID3Frame) bool) {
+	var frames []*ID3Frame
+	replaced := false
+
+	for _, f := range t.Frames {
+		if !match(f) {
+			frames = append(frames, f)
+			continue
+		}
+
+		if !replaced {
+			frames = append(frames, frame)
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		frames = append(frames, frame)
+	}
+
+	t.Frames = frames
+}
+
+// textEncoding returns the text encoding used to write provided values: UTF-8 in version 4,
+// ISO-8859-1 in version 3 if all values are representable, else UTF-16.
+func (t *ID3) textEncoding(values ...string) byte {
+	if t.version == 4 {
+		return ID3UTF8
+	}
+
+	for _, v := range values {
+		for _, r := range v {
+			if r > 0xFF {
+				return ID3UTF16
+			}
+		}
+	}
+
+	return ID3Latin1
+}
+
+// id3Terminator returns the string terminator of provided text encoding.
+func id3Terminator(enc byte) []byte {
+	if enc == ID3UTF16 || enc == ID3UTF16BE {
+		return []byte{0, 0}
+	}
+
+	return []byte{0}
+}
+
+// encodeID3Text encodes provided value without terminator. UTF-16 is written little endian with byte order mark.
+func encodeID3Text(enc byte, value string) []byte {
+	switch enc {
+	case ID3Latin1:
+		data := make([]byte, 0, len(value))
+
+		for _, r := range value {
+			data = append(data, byte(r))
+		}
+
+		return data
+	case ID3UTF16, ID3UTF16BE:
+		var data []byte
+		var byteOrder binary.ByteOrder = binary.BigEndian
+
+		if enc == ID3UTF16 {
+			data = []byte{0xFF, 0xFE}
+			byteOrder = binary.LittleEndian
+		}
+
+		for _, u := range utf16.Encode([]rune(value)) {
+			unit := make([]byte, 2)
+			byteOrder.PutUint16(unit, u)
+			data = append(data, unit...)
+		}
+
+		return data
+	default:
+		return []byte(value)
+	}
+}
+
+// decodeID3Text decodes provided text without terminator.
+func decodeID3Text(enc byte, data []byte) string {
+	switch enc {
+	case ID3Latin1:
+		runes := make([]rune, len(data))
+
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+
+		return string(runes)
+	case ID3UTF16, ID3UTF16BE:
+		var byteOrder binary.ByteOrder = binary.BigEndian
+
+		if len(data) >= 2 && data[0] == 0xFF && data[1] == 0xFE {
+			byteOrder = binary.LittleEndian
+			data = data[2:]
+		} else if len(data) >= 2 && data[0] == 0xFE && data[1] == 0xFF {
+			data = data[2:]
+		}
+
+		units := make([]uint16, len(data)/2)
+
+		for i := range units {
+			units[i] = byteOrder.Uint16(data[2*i : 2*i+2])
+		}
+
+		return string(utf16.Decode(units))
+	default:
+		return string(data)
+	}
+}
+
+// readID3Text decodes the first terminated text of provided data and returns it with the number of bytes read
+// including the terminator.
+func readID3Text(enc byte, data []byte) (string, int) {
+	terminator := id3Terminator(enc)
+	step := len(terminator)
+
+	for i := 0; i+step <= len(data); i += step {
+		if bytes.Equal(data[i:i+step], terminator) {
+			return decodeID3Text(enc, data[:i]), i + step
+		}
+	}
+
+	return decodeID3Text(enc, data), len(data)
+}
+
+// splitID3Text decodes all terminated texts of provided data.
+func splitID3Text(enc byte, data []byte) []string {
+	var values []string
+
+	for len(data) > 0 {
+		value, n := readID3Text(enc, data)
+		values = append(values, value)
+		data = data[n:]
+	}
+
+	return values
+}
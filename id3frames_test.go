@@ -0,0 +1,116 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestID3Text(t *testing.T) {
+	v3, _ := EncodeID3Chunk(ID3RIFFID, binary.LittleEndian, 3)
+	v3.SetText(ID3Artist, "Björk", "Ωmega")
+	decoded, _ := DecodeID3Chunk(v3.Bytes())
+
+	assertEqual(t, decoded.Text(ID3Artist), "Björk/Ωmega", "Text version 3")
+	assertEqual(t, decoded.Frame(ID3Artist).Data[0], ID3UTF16, "encoding version 3")
+
+	v4, _ := EncodeID3Chunk(ID3RIFFID, binary.LittleEndian, 4)
+	v4.SetText(ID3Artist, "Björk", "Ωmega")
+	decoded, _ = DecodeID3Chunk(v4.Bytes())
+
+	assertEqual(t, strings.Join(decoded.TextValues(ID3Artist), ","), "Björk,Ωmega", "TextValues version 4")
+	assertEqual(t, decoded.Frame(ID3Artist).Data[0], ID3UTF8, "encoding version 4")
+
+	v4.SetText(ID3Artist)
+
+	assertEqual(t, v4.Frame(ID3Artist), (*ID3Frame)(nil), "removed frame")
+	assertEqual(t, v4.Text(ID3Album), "", "Text not found")
+}
+
+func TestDecodeID3Text(t *testing.T) {
+	assertEqual(t, decodeID3Text(ID3Latin1, []byte{'a', 0xE9}), "aé", "Latin1")
+	assertEqual(t, decodeID3Text(ID3UTF16, []byte{0xFE, 0xFF, 0, 'a'}), "a", "UTF-16 big endian BOM")
+	assertEqual(t, decodeID3Text(ID3UTF16, []byte{0xFF, 0xFE, 'a', 0}), "a", "UTF-16 little endian BOM")
+	assertEqual(t, decodeID3Text(ID3UTF16BE, []byte{0, 'a'}), "a", "UTF-16 big endian")
+	assertEqual(t, strings.Join(splitID3Text(ID3UTF16BE, []byte{0, 'a', 0, 0, 1, 0}), ","), "a,Ā", "split UTF-16")
+}
+
+func TestID3Comments(t *testing.T) {
+	tag, _ := EncodeID3Chunk(ID3ID, binary.BigEndian, 3)
+	tag.SetComment(&ID3CommentFrame{Language: "eng", Description: "", Text: "first"})
+	tag.SetComment(&ID3CommentFrame{Language: "eng", Description: "", Text: "second"})
+	tag.SetComment(&ID3CommentFrame{Language: "deu", Description: "d", Text: "dritte"})
+	decoded, _ := DecodeID3Chunk(tag.Bytes())
+	comments := decoded.Comments()
+
+	assertEqual(t, len(comments), 2, "Comments length")
+	assertEqual(t, *comments[0], ID3CommentFrame{Language: "eng", Text: "second"}, "Comment")
+	assertEqual(t, *comments[1], ID3CommentFrame{Language: "deu", Description: "d", Text: "dritte"}, "Comment")
+}
+
+func TestID3Pictures(t *testing.T) {
+	tag, _ := EncodeID3Chunk(ID3ID, binary.BigEndian, 3)
+	tag.SetPicture(&ID3PictureFrame{MIMEType: "image/png", PictureType: 3, Description: "Cover ♪", Data: []byte{0x89, 'P', 'N', 'G', 0, 0}})
+	decoded, _ := DecodeID3Chunk(tag.Bytes())
+	pictures := decoded.Pictures()
+
+	assertEqual(t, len(pictures), 1, "Pictures length")
+	assertEqual(t, pictures[0].MIMEType, "image/png", "MIMEType")
+	assertEqual(t, pictures[0].PictureType, byte(3), "PictureType")
+	assertEqual(t, pictures[0].Description, "Cover ♪", "Description")
+	assertEqual(t, bytes.Equal(pictures[0].Data, []byte{0x89, 'P', 'N', 'G', 0, 0}), true, "Data")
+
+	decoded.SetPicture(&ID3PictureFrame{MIMEType: "image/jpeg", PictureType: 3, Description: "Cover ♪", Data: []byte{0xFF, 0xD8}})
+
+	assertEqual(t, len(decoded.Pictures()), 1, "Pictures length after replace")
+	assertEqual(t, decoded.Pictures()[0].MIMEType, "image/jpeg", "MIMEType after replace")
+}
+
+func TestID3UserTexts(t *testing.T) {
+	tag, _ := EncodeID3Chunk(ID3ID, binary.BigEndian, 4)
+	tag.SetUserText("CATALOG", "123")
+	tag.SetUserText("LABEL", "label")
+	tag.SetUserText("CATALOG", "456")
+	decoded, _ := DecodeID3Chunk(tag.Bytes())
+
+	assertEqual(t, len(decoded.UserTexts()), 2, "UserTexts length")
+	assertEqual(t, decoded.UserText("CATALOG"), "456", "UserText")
+	assertEqual(t, decoded.UserText("LABEL"), "label", "UserText")
+	assertEqual(t, decoded.UserText("UNKNOWN"), "", "UserText not found")
+}
+
+func TestID3Privates(t *testing.T) {
+	tag, _ := EncodeID3Chunk(ID3ID, binary.BigEndian, 4)
+	tag.AddPrivate(&ID3PrivateFrame{Owner: "www.example.com", Data: []byte{1, 0, 2}})
+	decoded, _ := DecodeID3Chunk(tag.Bytes())
+	privates := decoded.Privates()
+
+	assertEqual(t, len(privates), 1, "Privates length")
+	assertEqual(t, privates[0].Owner, "www.example.com", "Owner")
+	assertEqual(t, bytes.Equal(privates[0].Data, []byte{1, 0, 2}), true, "Data")
+}
+
+func TestID3Chapters(t *testing.T) {
+	for _, version := range []int{3, 4} {
+		tag, _ := EncodeID3Chunk(ID3ID, binary.BigEndian, version)
+		tag.SetUnsynchronisation(true)
+		title, _ := EncodeID3Chunk(ID3ID, binary.BigEndian, version)
+		title.SetText(ID3Title, "Intro")
+		tag.SetChapter(&ID3ChapterFrame{ElementID: "ch0", StartTime: 0, EndTime: 1000, StartOffset: 0xFFFFFFFF, EndOffset: 0xFFFFFFFF, Frames: title.Frames})
+		tag.SetChapter(&ID3ChapterFrame{ElementID: "ch1", StartTime: 1000, EndTime: 2000, StartOffset: 0xFFFFFFFF, EndOffset: 0xFFFFFFFF})
+		decoded, err := DecodeID3Chunk(tag.Bytes())
+
+		assertNil(t, err, "err")
+
+		chapters := decoded.Chapters()
+
+		assertEqual(t, len(chapters), 2, "Chapters length")
+		assertEqual(t, chapters[0].ElementID, "ch0", "ElementID")
+		assertEqual(t, chapters[0].EndTime, uint32(1000), "EndTime")
+		assertEqual(t, chapters[0].StartOffset, uint32(0xFFFFFFFF), "StartOffset")
+		assertEqual(t, chapters[0].Title(), "Intro", "Title")
+		assertEqual(t, chapters[1].StartTime, uint32(1000), "StartTime")
+		assertEqual(t, chapters[1].Title(), "", "Title without frames")
+	}
+}
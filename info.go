@@ -0,0 +1,423 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// INFO sub chunk IDs
+const (
+	// InfoArchivalLocation IARL
+	InfoArchivalLocation = "IARL"
+	// InfoArtist IART
+	InfoArtist = "IART"
+	// InfoCommissioned ICMS
+	InfoCommissioned = "ICMS"
+	// InfoComment ICMT
+	InfoComment = "ICMT"
+	// InfoCopyright ICOP
+	InfoCopyright = "ICOP"
+	// InfoCreationDate ICRD
+	InfoCreationDate = "ICRD"
+	// InfoCropped ICRP
+	InfoCropped = "ICRP"
+	// InfoDimensions IDIM
+	InfoDimensions = "IDIM"
+	// InfoDotsPerInch IDPI
+	InfoDotsPerInch = "IDPI"
+	// InfoEngineer IENG
+	InfoEngineer = "IENG"
+	// InfoGenre IGNR
+	InfoGenre = "IGNR"
+	// InfoKeywords IKEY
+	InfoKeywords = "IKEY"
+	// InfoLightness ILGT
+	InfoLightness = "ILGT"
+	// InfoMedium IMED
+	InfoMedium = "IMED"
+	// InfoName INAM
+	InfoName = "INAM"
+	// InfoPalette IPLT
+	InfoPalette = "IPLT"
+	// InfoProduct IPRD
+	InfoProduct = "IPRD"
+	// InfoSubject ISBJ
+	InfoSubject = "ISBJ"
+	// InfoSoftware ISFT
+	InfoSoftware = "ISFT"
+	// InfoSharpness ISHP
+	InfoSharpness = "ISHP"
+	// InfoSource ISRC
+	InfoSource = "ISRC"
+	// InfoSourceForm ISRF
+	InfoSourceForm = "ISRF"
+	// InfoTechnician ITCH
+	InfoTechnician = "ITCH"
+	// InfoTrackNumber ITRK
+	InfoTrackNumber = "ITRK"
+)
+
+// subChunk is a chunk contained in the data of a list chunk 'LIST'.
+type subChunk struct {
+	id   string
+	data []byte
+}
+
+// Info is RIFF list chunk 'LIST' of type 'INFO' containing text information about the file.
+// Each value is stored in a sub chunk identified by an INFO ID, the order and unknown sub chunks are preserved.
+type Info struct {
+	*Header
+	entries []*subChunk
+}
+
+// IDs returns the INFO IDs of all values in order.
+func (i *Info) IDs() []string {
+	ids := make([]string, len(i.entries))
+
+	for n, entry := range i.entries {
+		ids[n] = entry.id
+	}
+
+	return ids
+}
+
+// Value returns the value of provided INFO ID, an empty string if not set.
+func (i *Info) Value(id string) string {
+	for _, entry := range i.entries {
+		if entry.id == id {
+			return nullTermToString(entry.data)
+		}
+	}
+
+	return ""
+}
+
+// SetValue sets the value of provided INFO ID, a 4 character code. The value is null terminated.
+// An empty value removes the INFO ID.
+func (i *Info) SetValue(id string, value string) {
+	if value == "" {
+		i.Remove(id)
+		return
+	}
+
+	data := []byte(terminate(value, len(value)+1))
+
+	for _, entry := range i.entries {
+		if entry.id == id {
+			entry.data = data
+			return
+		}
+	}
+
+	i.entries = append(i.entries, &subChunk{id: id, data: data})
+}
+
+// Remove removes provided INFO ID.
+func (i *Info) Remove(id string) {
+	var entries []*subChunk
+
+	for _, entry := range i.entries {
+		if entry.id != id {
+			entries = append(entries, entry)
+		}
+	}
+
+	i.entries = entries
+}
+
+// ArchivalLocation indicates where the subject of the file is archived (IARL).
+func (i *Info) ArchivalLocation() string {
+	return i.Value(InfoArchivalLocation)
+}
+
+// SetArchivalLocation (IARL)
+func (i *Info) SetArchivalLocation(value string) {
+	i.SetValue(InfoArchivalLocation, value)
+}
+
+// Artist lists the artist of the original subject of the file (IART).
+func (i *Info) Artist() string {
+	return i.Value(InfoArtist)
+}
+
+// SetArtist (IART)
+func (i *Info) SetArtist(value string) {
+	i.SetValue(InfoArtist, value)
+}
+
+// Commissioned lists the name of the person or organization that commissioned the subject of the file (ICMS).
+func (i *Info) Commissioned() string {
+	return i.Value(InfoCommissioned)
+}
+
+// SetCommissioned (ICMS)
+func (i *Info) SetCommissioned(value string) {
+	i.SetValue(InfoCommissioned, value)
+}
+
+// Comment provides general comments about the file or the subject of the file (ICMT).
+func (i *Info) Comment() string {
+	return i.Value(InfoComment)
+}
+
+// SetComment (ICMT)
+func (i *Info) SetComment(value string) {
+	i.SetValue(InfoComment, value)
+}
+
+// Copyright records the copyright information for the file (ICOP).
+func (i *Info) Copyright() string {
+	return i.Value(InfoCopyright)
+}
+
+// SetCopyright (ICOP)
+func (i *Info) SetCopyright(value string) {
+	i.SetValue(InfoCopyright, value)
+}
+
+// CreationDate specifies the date the subject of the file was created, YYYY-MM-DD (ICRD).
+func (i *Info) CreationDate() string {
+	return i.Value(InfoCreationDate)
+}
+
+// SetCreationDate (ICRD)
+func (i *Info) SetCreationDate(value string) {
+	i.SetValue(InfoCreationDate, value)
+}
+
+// Engineer stores the name of the engineer who worked on the file (IENG).
+func (i *Info) Engineer() string {
+	return i.Value(InfoEngineer)
+}
+
+// SetEngineer (IENG)
+func (i *Info) SetEngineer(value string) {
+	i.SetValue(InfoEngineer, value)
+}
+
+// Genre describes the original work (IGNR).
+func (i *Info) Genre() string {
+	return i.Value(InfoGenre)
+}
+
+// SetGenre (IGNR)
+func (i *Info) SetGenre(value string) {
+	i.SetValue(InfoGenre, value)
+}
+
+// Keywords provides a list of keywords separated by semicolon (IKEY).
+func (i *Info) Keywords() string {
+	return i.Value(InfoKeywords)
+}
+
+// SetKeywords (IKEY)
+func (i *Info) SetKeywords(value string) {
+	i.SetValue(InfoKeywords, value)
+}
+
+// Medium describes the original subject of the file (IMED).
+func (i *Info) Medium() string {
+	return i.Value(InfoMedium)
+}
+
+// SetMedium (IMED)
+func (i *Info) SetMedium(value string) {
+	i.SetValue(InfoMedium, value)
+}
+
+// Name stores the title of the subject of the file (INAM).
+func (i *Info) Name() string {
+	return i.Value(InfoName)
+}
+
+// SetName (INAM)
+func (i *Info) SetName(value string) {
+	i.SetValue(InfoName, value)
+}
+
+// Product specifies the name of the title the file was originally intended for (IPRD).
+func (i *Info) Product() string {
+	return i.Value(InfoProduct)
+}
+
+// SetProduct (IPRD)
+func (i *Info) SetProduct(value string) {
+	i.SetValue(InfoProduct, value)
+}
+
+// Subject describes the contents of the file (ISBJ).
+func (i *Info) Subject() string {
+	return i.Value(InfoSubject)
+}
+
+// SetSubject (ISBJ)
+func (i *Info) SetSubject(value string) {
+	i.SetValue(InfoSubject, value)
+}
+
+// Software identifies the name of the software package used to create the file (ISFT).
+func (i *Info) Software() string {
+	return i.Value(InfoSoftware)
+}
+
+// SetSoftware (ISFT)
+func (i *Info) SetSoftware(value string) {
+	i.SetValue(InfoSoftware, value)
+}
+
+// Source identifies the name of the person or organization who supplied the original subject of the file (ISRC).
+func (i *Info) Source() string {
+	return i.Value(InfoSource)
+}
+
+// SetSource (ISRC)
+func (i *Info) SetSource(value string) {
+	i.SetValue(InfoSource, value)
+}
+
+// SourceForm identifies the original form of the material that was digitized (ISRF).
+func (i *Info) SourceForm() string {
+	return i.Value(InfoSourceForm)
+}
+
+// SetSourceForm (ISRF)
+func (i *Info) SetSourceForm(value string) {
+	i.SetValue(InfoSourceForm, value)
+}
+
+// Technician identifies the technician who digitized the subject file (ITCH).
+func (i *Info) Technician() string {
+	return i.Value(InfoTechnician)
+}
+
+// SetTechnician (ITCH)
+func (i *Info) SetTechnician(value string) {
+	i.SetValue(InfoTechnician, value)
+}
+
+// TrackNumber is the track number of the subject of the file (ITRK).
+func (i *Info) TrackNumber() string {
+	return i.Value(InfoTrackNumber)
+}
+
+// SetTrackNumber (ITRK)
+func (i *Info) SetTrackNumber(value string) {
+	i.SetValue(InfoTrackNumber, value)
+}
+
+// String returns string represensation of chunk.
+func (i *Info) String() string {
+	lines := make([]string, len(i.entries))
+
+	for n, entry := range i.entries {
+		lines[n] = fmt.Sprintf("%s: %s", entry.id, nullTermToString(entry.data))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Bytes converts Info to byte array. A new Header with id 'LIST' is created.
+//
+// Header size is set to real data size. A minimum amount of 12 bytes is returned.
+// chunk header - 8 bytes
+// list type 'INFO' - 4 bytes
+// sub chunks - id (4 bytes), size (4 bytes), null terminated value and a padding byte if size is odd
+func (i *Info) Bytes() []byte {
+	return encodeList(INFOID, i.entries)
+}
+
+// EncodeInfoChunk returns encoded chunk 'LIST' of type 'INFO' containing provided values by INFO ID.
+// Values are ordered by INFO ID.
+func EncodeInfoChunk(values map[string]string) *Info {
+	i := &Info{}
+	ids := make([]string, 0, len(values))
+
+	for id := range values {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		i.SetValue(id, values[id])
+	}
+
+	i.Header = decodeChunkHeader(i.Bytes(), 0, binary.LittleEndian)
+
+	return i
+}
+
+// DecodeInfoChunk decodes provided byte array to Info.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// list type 'INFO' - 4 bytes (min. requirement for successful decoding)
+// sub chunks - not restricted amount of bytes
+func DecodeInfoChunk(data []byte) (*Info, error) {
+	header, entries, err := decodeList(data, INFOID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Info{Header: header, entries: entries}, nil
+}
+
+// encodeList returns chunk 'LIST' of provided list type containing provided sub chunks.
+func encodeList(listType string, entries []*subChunk) []byte {
+	byteOrder := binary.LittleEndian
+	data := []byte(listType)
+
+	for _, entry := range entries {
+		header := EncodeChunkHeader(CreateFourCC(entry.id), uint32(len(entry.data)), byteOrder)
+		data = append(data, header.Bytes()...)
+		data = append(data, pad(entry.data)...)
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(LISTID), uint32(len(data)), byteOrder)
+
+	return append(header.Bytes(), data...)
+}
+
+// decodeList decodes the header and sub chunks of chunk 'LIST' of provided list type.
+func decodeList(data []byte, listType string) (*Header, []*subChunk, error) {
+	if len(data) < int(ContainerHeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", ContainerHeaderSizeBytes)
+		return nil, nil, errors.New(msg)
+	}
+
+	byteOrder := binary.LittleEndian
+	header := decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+
+	if header.ID() != LISTID || string(data[HeaderSizeBytes:ContainerHeaderSizeBytes]) != listType {
+		msg := fmt.Sprintf("chunk is not of type '%s' '%s'", LISTID, listType)
+		return nil, nil, errors.New(msg)
+	}
+
+	end := int(HeaderSizeBytes + header.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	var entries []*subChunk
+	pos := int(ContainerHeaderSizeBytes)
+
+	for pos+int(HeaderSizeBytes) <= end {
+		sub := decodeChunkHeader(data[pos:pos+int(HeaderSizeBytes)], uint32(pos), byteOrder)
+		start := pos + int(HeaderSizeBytes)
+		size := int(sub.Size())
+
+		if size > end-start {
+			size = end - start
+		}
+
+		entries = append(entries, &subChunk{id: sub.ID(), data: append([]byte{}, data[start:start+size]...)})
+		pos = start + size + size%2
+	}
+
+	return header, entries, nil
+}
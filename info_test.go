@@ -0,0 +1,53 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestEncodeInfoChunk(t *testing.T) {
+	chunk := EncodeInfoChunk(map[string]string{InfoName: "Title", InfoArtist: "Artist", InfoSoftware: "chunk"})
+
+	assertEqual(t, chunk.ID(), LISTID, "ID")
+	assertEqual(t, chunk.Size(), uint32(4+8+8+8+6+8+6), "Size")
+	assertEqual(t, chunk.Name(), "Title", "Name")
+	assertEqual(t, chunk.Artist(), "Artist", "Artist")
+	assertEqual(t, chunk.Software(), "chunk", "Software")
+	assertEqual(t, strings.Join(chunk.IDs(), ","), strings.Join([]string{InfoArtist, InfoName, InfoSoftware}, ","), "IDs")
+
+	chunk.SetComment("Comment")
+	chunk.SetArtist("")
+
+	assertEqual(t, strings.Join(chunk.IDs(), ","), strings.Join([]string{InfoName, InfoSoftware, InfoComment}, ","), "IDs")
+	assertEqual(t, chunk.Comment(), "Comment", "Comment")
+}
+
+func TestDecodeInfoChunk(t *testing.T) {
+	data := []byte("LIST\x00\x00\x00\x00INFO")
+	data = append(data, []byte("INAM\x05\x00\x00\x00Name\x00\x00")...)
+	data = append(data, []byte("IXYZ\x03\x00\x00\x00abc\x00")...)
+	data = append(data, []byte("ICMT\x07\x00\x00\x00Comment")...)
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(data))-HeaderSizeBytes)
+	chunk, err := DecodeInfoChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, chunk.Name(), "Name", "Name")
+	assertEqual(t, chunk.Value("IXYZ"), "abc", "Value of unknown ID")
+	assertEqual(t, chunk.Comment(), "Comment", "Comment without terminating null")
+	assertEqual(t, strings.Join(chunk.IDs(), ","), strings.Join([]string{InfoName, "IXYZ", InfoComment}, ","), "IDs")
+
+	encoded := chunk.Bytes()
+
+	assertEqual(t, bytes.Equal(encoded[HeaderSizeBytes:], append(data[HeaderSizeBytes:], 0)), true, "Bytes")
+	assertEqual(t, binary.LittleEndian.Uint32(encoded[4:8]), uint32(len(data))+1-HeaderSizeBytes, "Bytes size with padding")
+
+	_, err = DecodeInfoChunk([]byte("LIST\x04\x00\x00\x00adtl"))
+
+	assertNotNil(t, err, "err with list type 'adtl'")
+
+	_, err = DecodeInfoChunk(data[:HeaderSizeBytes])
+
+	assertNotNil(t, err, "err with short data")
+}
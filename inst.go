@@ -0,0 +1,117 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Inst is RIFF instrument chunk 'inst' describing how to play the sound data by a sampler.
+type Inst struct {
+	*Header
+	unshiftedNote uint8
+	fineTune      int8
+	gain          int8
+	lowNote       uint8
+	highNote      uint8
+	lowVelocity   uint8
+	highVelocity  uint8
+}
+
+// UnshiftedNote is the MIDI note (0 - 127) playing the sound data at its original pitch.
+func (i *Inst) UnshiftedNote() int {
+	return int(i.unshiftedNote)
+}
+
+// FineTune is the pitch shift in cents (-50 - 50) applied when playing.
+func (i *Inst) FineTune() int {
+	return int(i.fineTune)
+}
+
+// Gain is the gain in dB applied when playing.
+func (i *Inst) Gain() int {
+	return int(i.gain)
+}
+
+// LowNote is the lowest MIDI note of the note range.
+func (i *Inst) LowNote() int {
+	return int(i.lowNote)
+}
+
+// HighNote is the highest MIDI note of the note range.
+func (i *Inst) HighNote() int {
+	return int(i.highNote)
+}
+
+// LowVelocity is the lowest MIDI velocity (1 - 127) of the velocity range.
+func (i *Inst) LowVelocity() int {
+	return int(i.lowVelocity)
+}
+
+// HighVelocity is the highest MIDI velocity (1 - 127) of the velocity range.
+func (i *Inst) HighVelocity() int {
+	return int(i.highVelocity)
+}
+
+// String returns string represensation of chunk.
+func (i *Inst) String() string {
+	return fmt.Sprintf("Unshifted note: %d\nFine tune: %d\nGain: %d\nLow note: %d\nHigh note: %d\nLow velocity: %d\nHigh velocity: %d",
+		i.UnshiftedNote(), i.FineTune(), i.Gain(), i.LowNote(), i.HighNote(), i.LowVelocity(), i.HighVelocity())
+}
+
+// Bytes converts Inst to byte array. A new Header with id 'inst' is created.
+//
+// Header size is set to real data size. An amount of 16 bytes is returned.
+// chunk header - 8 bytes
+// unshifted note - 1 byte
+// fine tune - 1 byte
+// gain - 1 byte
+// low note - 1 byte
+// high note - 1 byte
+// low velocity - 1 byte
+// high velocity - 1 byte
+//
+// A padding byte is added as size is odd. This optional byte is not reflected in size.
+func (i *Inst) Bytes() []byte {
+	data := []byte{i.unshiftedNote, uint8(i.fineTune), uint8(i.gain), i.lowNote, i.highNote, i.lowVelocity, i.highVelocity}
+	header := EncodeChunkHeader(CreateFourCC(INSTID), uint32(len(data)), binary.LittleEndian)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeInstChunk returns encoded chunk 'inst' by provided parameters.
+func EncodeInstChunk(unshiftedNote uint8, fineTune int8, gain int8, lowNote uint8, highNote uint8, lowVelocity uint8, highVelocity uint8) *Inst {
+	header := EncodeChunkHeader(CreateFourCC(INSTID), 7, binary.LittleEndian)
+
+	return &Inst{Header: header, unshiftedNote: unshiftedNote, fineTune: fineTune, gain: gain, lowNote: lowNote, highNote: highNote, lowVelocity: lowVelocity, highVelocity: highVelocity}
+}
+
+// DecodeInstChunk decodes provided byte array to Inst.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// data - 7 bytes
+func DecodeInstChunk(data []byte) (*Inst, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	i := &Inst{}
+	byteOrder := binary.LittleEndian
+	i.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	fields := []interface{}{&i.unshiftedNote, &i.fineTune, &i.gain, &i.lowNote, &i.highNote, &i.lowVelocity, &i.highVelocity}
+
+	for _, f := range fields {
+		err := binary.Read(buf, byteOrder, f)
+
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return i, nil
+}
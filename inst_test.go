@@ -0,0 +1,30 @@
+package chunk
+
+import "testing"
+
+func TestEncodeInstChunk(t *testing.T) {
+	chunk := EncodeInstChunk(60, -10, 3, 48, 72, 1, 127)
+
+	assertEqual(t, chunk.ID(), INSTID, "ID")
+	assertEqual(t, chunk.Size(), uint32(7), "Size")
+	assertEqual(t, len(chunk.Bytes()), 16, "Bytes length with padding")
+	assertEqual(t, chunk.FineTune(), -10, "FineTune")
+}
+
+func TestDecodeInstChunk(t *testing.T) {
+	data := EncodeInstChunk(60, -10, -6, 48, 72, 1, 127).Bytes()
+	chunk, err := DecodeInstChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, chunk.UnshiftedNote(), 60, "UnshiftedNote")
+	assertEqual(t, chunk.FineTune(), -10, "FineTune")
+	assertEqual(t, chunk.Gain(), -6, "Gain")
+	assertEqual(t, chunk.LowNote(), 48, "LowNote")
+	assertEqual(t, chunk.HighNote(), 72, "HighNote")
+	assertEqual(t, chunk.LowVelocity(), 1, "LowVelocity")
+	assertEqual(t, chunk.HighVelocity(), 127, "HighVelocity")
+
+	_, err = DecodeInstChunk(data[:HeaderSizeBytes+3])
+
+	assertNotNil(t, err, "err with short data")
+}
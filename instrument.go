@@ -0,0 +1,180 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// NoLooping is the play mode of a loop not played.
+	NoLooping int16 = 0
+	// ForwardLooping is the play mode of a loop played from begin to end.
+	ForwardLooping int16 = 1
+	// ForwardBackwardLooping is the play mode of a loop played alternating forward and backward.
+	ForwardBackwardLooping int16 = 2
+	// instrumentSize is the data size of an AIFF instrument chunk.
+	instrumentSize = 20
+)
+
+// AIFFLoop is a loop of AIFF instrument chunk 'INST' referencing begin and end marker of marker chunk 'MARK'.
+type AIFFLoop struct {
+	PlayMode      int16
+	BeginMarkerID int16
+	EndMarkerID   int16
+}
+
+// Resolve returns the positions of begin and end marker of the loop in provided marker chunk.
+func (l AIFFLoop) Resolve(mark *MARK) (uint32, uint32, error) {
+	if mark == nil {
+		return 0, 0, errors.New("no marker chunk provided")
+	}
+
+	begin := mark.Marker(l.BeginMarkerID)
+	end := mark.Marker(l.EndMarkerID)
+
+	if begin == nil || end == nil {
+		msg := fmt.Sprintf("marker %d or %d not found", l.BeginMarkerID, l.EndMarkerID)
+		return 0, 0, errors.New(msg)
+	}
+
+	return begin.Position, end.Position, nil
+}
+
+// String returns string represensation of loop.
+func (l AIFFLoop) String() string {
+	return fmt.Sprintf("Play mode: %d Begin marker: %d End marker: %d", l.PlayMode, l.BeginMarkerID, l.EndMarkerID)
+}
+
+// Instrument is AIFF / AIFF-C instrument chunk 'INST' describing how to play the sound data by a sampler.
+type Instrument struct {
+	*Header
+	baseNote     int8
+	detune       int8
+	lowNote      int8
+	highNote     int8
+	lowVelocity  int8
+	highVelocity int8
+	gain         int16
+	sustainLoop  AIFFLoop
+	releaseLoop  AIFFLoop
+}
+
+// BaseNote is the MIDI note (0 - 127) playing the sound data at its original pitch.
+func (i *Instrument) BaseNote() int {
+	return int(i.baseNote)
+}
+
+// Detune is the pitch shift in cents (-50 - 50) applied when playing.
+func (i *Instrument) Detune() int {
+	return int(i.detune)
+}
+
+// LowNote is the lowest MIDI note of the note range.
+func (i *Instrument) LowNote() int {
+	return int(i.lowNote)
+}
+
+// HighNote is the highest MIDI note of the note range.
+func (i *Instrument) HighNote() int {
+	return int(i.highNote)
+}
+
+// LowVelocity is the lowest MIDI velocity (1 - 127) of the velocity range.
+func (i *Instrument) LowVelocity() int {
+	return int(i.lowVelocity)
+}
+
+// HighVelocity is the highest MIDI velocity (1 - 127) of the velocity range.
+func (i *Instrument) HighVelocity() int {
+	return int(i.highVelocity)
+}
+
+// Gain is the gain in dB applied when playing.
+func (i *Instrument) Gain() int {
+	return int(i.gain)
+}
+
+// SustainLoop is the loop played while the note is held.
+func (i *Instrument) SustainLoop() AIFFLoop {
+	return i.sustainLoop
+}
+
+// ReleaseLoop is the loop played after the note is released.
+func (i *Instrument) ReleaseLoop() AIFFLoop {
+	return i.releaseLoop
+}
+
+// String returns string represensation of chunk.
+func (i *Instrument) String() string {
+	return fmt.Sprintf("Base note: %d\nDetune: %d\nLow note: %d\nHigh note: %d\nLow velocity: %d\nHigh velocity: %d\nGain: %d\nSustain loop: %s\nRelease loop: %s",
+		i.BaseNote(), i.Detune(), i.LowNote(), i.HighNote(), i.LowVelocity(), i.HighVelocity(), i.Gain(), i.sustainLoop, i.releaseLoop)
+}
+
+// Bytes converts Instrument to byte array. A new Header with id 'INST' is created.
+//
+// Header size is set to real data size. An amount of 28 bytes is returned.
+// chunk header - 8 bytes
+// base note - 1 byte
+// detune - 1 byte
+// low note - 1 byte
+// high note - 1 byte
+// low velocity - 1 byte
+// high velocity - 1 byte
+// gain - 2 bytes
+// sustain loop - 6 bytes: play mode, begin marker id, end marker id
+// release loop - 6 bytes: play mode, begin marker id, end marker id
+func (i *Instrument) Bytes() []byte {
+	byteOrder := binary.BigEndian
+	data := make([]byte, instrumentSize)
+	copy(data[0:6], []byte{uint8(i.baseNote), uint8(i.detune), uint8(i.lowNote), uint8(i.highNote), uint8(i.lowVelocity), uint8(i.highVelocity)})
+	values := []int16{i.gain, i.sustainLoop.PlayMode, i.sustainLoop.BeginMarkerID, i.sustainLoop.EndMarkerID,
+		i.releaseLoop.PlayMode, i.releaseLoop.BeginMarkerID, i.releaseLoop.EndMarkerID}
+
+	for n, value := range values {
+		byteOrder.PutUint16(data[6+2*n:8+2*n], uint16(value))
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(INSTRUMENTID), uint32(len(data)), byteOrder)
+
+	return append(header.Bytes(), data...)
+}
+
+// EncodeInstrumentChunk returns encoded chunk 'INST' by provided parameters.
+func EncodeInstrumentChunk(baseNote int8, detune int8, lowNote int8, highNote int8, lowVelocity int8, highVelocity int8, gain int16, sustainLoop AIFFLoop, releaseLoop AIFFLoop) *Instrument {
+	header := EncodeChunkHeader(CreateFourCC(INSTRUMENTID), instrumentSize, binary.BigEndian)
+
+	return &Instrument{Header: header, baseNote: baseNote, detune: detune, lowNote: lowNote, highNote: highNote,
+		lowVelocity: lowVelocity, highVelocity: highVelocity, gain: gain, sustainLoop: sustainLoop, releaseLoop: releaseLoop}
+}
+
+// DecodeInstrumentChunk decodes provided byte array to Instrument.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// data - 20 bytes
+func DecodeInstrumentChunk(data []byte) (*Instrument, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	i := &Instrument{}
+	byteOrder := binary.BigEndian
+	i.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	fields := []interface{}{&i.baseNote, &i.detune, &i.lowNote, &i.highNote, &i.lowVelocity, &i.highVelocity, &i.gain,
+		&i.sustainLoop.PlayMode, &i.sustainLoop.BeginMarkerID, &i.sustainLoop.EndMarkerID,
+		&i.releaseLoop.PlayMode, &i.releaseLoop.BeginMarkerID, &i.releaseLoop.EndMarkerID}
+
+	for _, f := range fields {
+		err := binary.Read(buf, byteOrder, f)
+
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return i, nil
+}
@@ -0,0 +1,74 @@
+package chunk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncodeInstrumentChunk(t *testing.T) {
+	chunk := EncodeInstrumentChunk(60, 0, 0, 127, 1, 127, 0, AIFFLoop{ForwardLooping, 1, 2}, AIFFLoop{NoLooping, 0, 0})
+
+	assertEqual(t, chunk.ID(), INSTRUMENTID, "ID")
+	assertEqual(t, chunk.Size(), uint32(20), "Size")
+	assertEqual(t, len(chunk.Bytes()), 28, "Bytes length")
+}
+
+func TestDecodeInstrumentChunk(t *testing.T) {
+	data := EncodeInstrumentChunk(60, -5, 10, 100, 1, 127, -3, AIFFLoop{ForwardBackwardLooping, 1, 2}, AIFFLoop{ForwardLooping, 3, 4}).Bytes()
+	chunk, err := DecodeInstrumentChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, chunk.BaseNote(), 60, "BaseNote")
+	assertEqual(t, chunk.Detune(), -5, "Detune")
+	assertEqual(t, chunk.LowNote(), 10, "LowNote")
+	assertEqual(t, chunk.HighNote(), 100, "HighNote")
+	assertEqual(t, chunk.LowVelocity(), 1, "LowVelocity")
+	assertEqual(t, chunk.HighVelocity(), 127, "HighVelocity")
+	assertEqual(t, chunk.Gain(), -3, "Gain")
+	assertEqual(t, chunk.SustainLoop(), AIFFLoop{ForwardBackwardLooping, 1, 2}, "SustainLoop")
+	assertEqual(t, chunk.ReleaseLoop(), AIFFLoop{ForwardLooping, 3, 4}, "ReleaseLoop")
+
+	_, err = DecodeInstrumentChunk(data[:HeaderSizeBytes+10])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestResolveAIFFLoop(t *testing.T) {
+	mark := EncodeMARKChunk([]*AIFFMarker{{ID: 1, Position: 100}, {ID: 2, Position: 900}})
+	loop := AIFFLoop{ForwardLooping, 1, 2}
+	begin, end, err := loop.Resolve(mark)
+
+	assertNil(t, err, "err")
+	assertEqual(t, begin, uint32(100), "begin")
+	assertEqual(t, end, uint32(900), "end")
+
+	_, _, err = AIFFLoop{ForwardLooping, 1, 3}.Resolve(mark)
+
+	assertNotNil(t, err, "err with unknown marker")
+
+	// markers are moved and limited to the trimmed range
+	dir := t.TempDir()
+	data := createTestSine(0.5, 1000, 44100, 1000, 1)
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 1000, 16, 44100, CreateFourCC("NONE"), ""), 0, data)
+	aiff = append(aiff, mark.Bytes()...)
+	path := filepath.Join(dir, "in.aiff")
+	os.WriteFile(path, aiff, 0644)
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadAiff(in.Name(), in)
+	out, _ := os.Create(filepath.Join(dir, "out.aiff"))
+	defer out.Close()
+	err = Trim(out, in, container, 50, 500)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadAiff(out.Name(), out)
+	markData, _ := ReadChunk(out, container.FindHeaders(MARKID)[0])
+	trimmed, _ := DecodeMARKChunk(markData)
+	begin, end, _ = loop.Resolve(trimmed)
+
+	assertEqual(t, begin, uint32(50), "begin after trim")
+	assertEqual(t, end, uint32(500), "end after trim")
+}
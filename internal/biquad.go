@@ -0,0 +1,56 @@
+package internal
+
+import "math"
+
+// Biquad is a second order IIR filter (transposed direct form II) with normalized coefficients (a0 = 1).
+type Biquad struct {
+	B0, B1, B2 float64
+	A1, A2     float64
+	z1, z2     float64
+}
+
+// Process filters a single sample.
+func (f *Biquad) Process(x float64) float64 {
+	y := f.B0*x + f.z1
+	f.z1 = f.B1*x - f.A1*y + f.z2
+	f.z2 = f.B2*x - f.A2*y
+
+	return y
+}
+
+// KWeighting returns the two filter stages of the K-weighting filter of ITU-R BS.1770 for provided sample rate:
+// a high shelf modelling the acoustic effect of the head and a high pass (RLB weighting).
+func KWeighting(sampleRate int) (*Biquad, *Biquad) {
+	fs := float64(sampleRate)
+
+	// stage 1: high shelf
+	f0 := 1681.974450955533
+	g := 3.999843853973347
+	q := 0.7071752369554196
+	k := math.Tan(math.Pi * f0 / fs)
+	vh := math.Pow(10, g/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+	shelf := &Biquad{
+		B0: (vh + vb*k/q + k*k) / a0,
+		B1: 2 * (k*k - vh) / a0,
+		B2: (vh - vb*k/q + k*k) / a0,
+		A1: 2 * (k*k - 1) / a0,
+		A2: (1 - k/q + k*k) / a0,
+	}
+
+	// stage 2: high pass
+	f0 = 38.13547087602444
+	q = 0.5003270373238773
+	k = math.Tan(math.Pi * f0 / fs)
+	a0 = 1 + k/q + k*k
+	highPass := &Biquad{
+		B0: 1,
+		B1: -2,
+		B2: 1,
+		A1: 2 * (k*k - 1) / a0,
+		A2: (1 - k/q + k*k) / a0,
+	}
+
+	return shelf, highPass
+}
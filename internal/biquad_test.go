@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKWeighting(t *testing.T) {
+	shelf, highPass := KWeighting(48000)
+
+	// coefficients for 48 kHz as specified in ITU-R BS.1770
+	want := []float64{1.53512485958697, -2.69169618940638, 1.19839281085285, -1.69065929318241, 0.73248077421585}
+	got := []float64{shelf.B0, shelf.B1, shelf.B2, shelf.A1, shelf.A2}
+
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-8 {
+			t.Errorf("shelf coefficient %d is %.14f, want %.14f", i, got[i], want[i])
+		}
+	}
+
+	want = []float64{1, -2, 1, -1.99004745483398, 0.99007225036621}
+	got = []float64{highPass.B0, highPass.B1, highPass.B2, highPass.A1, highPass.A2}
+
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-8 {
+			t.Errorf("high pass coefficient %d is %.14f, want %.14f", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBiquad(t *testing.T) {
+	f := &Biquad{B0: 0.5, B1: 0.5}
+	out := []float64{f.Process(1), f.Process(0), f.Process(0)}
+	want := []float64{0.5, 0.5, 0}
+
+	for i := range want {
+		if out[i] != want[i] {
+			t.Errorf("output %d is %f, want %f", i, out[i], want[i])
+		}
+	}
+}
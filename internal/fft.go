@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"math"
+	"math/bits"
+)
+
+// FFT computes the discrete Fourier transform of the complex values re + i*im in place.
+// The length of re and im must be equal and a power of 2.
+func FFT(re []float64, im []float64) {
+	n := len(re)
+
+	if n < 2 {
+		return
+	}
+
+	// bit reversal permutation
+	shift := 64 - uint(bits.TrailingZeros(uint(n)))
+
+	for i := 0; i < n; i++ {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+
+		if j > i {
+			re[i], re[j] = re[j], re[i]
+			im[i], im[j] = im[j], im[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		step := -2 * math.Pi / float64(size)
+
+		for k := 0; k < half; k++ {
+			wRe := math.Cos(step * float64(k))
+			wIm := math.Sin(step * float64(k))
+
+			for start := 0; start < n; start += size {
+				i := start + k
+				j := i + half
+				tRe := wRe*re[j] - wIm*im[j]
+				tIm := wRe*im[j] + wIm*re[j]
+				re[j], im[j] = re[i]-tRe, im[i]-tIm
+				re[i], im[i] = re[i]+tRe, im[i]+tIm
+			}
+		}
+	}
+}
+
+// Hann returns the Hann window of provided size.
+func Hann(size int) []float64 {
+	window := make([]float64, size)
+
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(size))
+	}
+
+	return window
+}
@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFFT(t *testing.T) {
+	n := 64
+	re := make([]float64, n)
+	im := make([]float64, n)
+
+	for i := range re {
+		re[i] = math.Cos(2 * math.Pi * 5 * float64(i) / float64(n))
+	}
+
+	FFT(re, im)
+
+	for k := 0; k < n; k++ {
+		want := 0.
+
+		if k == 5 || k == n-5 {
+			want = float64(n) / 2
+		}
+
+		magnitude := math.Hypot(re[k], im[k])
+
+		if math.Abs(magnitude-want) > 1e-9 {
+			t.Errorf("bin %d magnitude is %f, want %f", k, magnitude, want)
+		}
+	}
+}
+
+func TestHann(t *testing.T) {
+	window := Hann(8)
+
+	if window[0] != 0 || math.Abs(window[4]-1) > 1e-12 || math.Abs(window[2]-window[6]) > 1e-12 {
+		t.Errorf("unexpected window %v", window)
+	}
+}
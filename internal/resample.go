@@ -0,0 +1,186 @@
+package internal
+
+import "math"
+
+const (
+	// zero crossings of the sinc function on each side of the filter
+	zeroCrossings = 32
+	// Kaiser window beta, approx. 90 dB stop band attenuation
+	kaiserBeta = 8.6
+	// cutoff frequency relative to the Nyquist frequency of the lower sample rate
+	rolloff = 0.95
+	// max. number of precomputed filter coefficients
+	maxBankSize = 1 << 20
+)
+
+// Resampler converts a stream of samples of a single channel from one sample rate to another.
+// A polyphase filter bank of windowed-sinc (Kaiser) filters is used, one filter for each
+// phase of the output sample position between two input samples.
+type Resampler struct {
+	up      int
+	down    int
+	half    int
+	cutoff  float64
+	bank    [][]float64
+	scratch []float64
+	buf     []float64
+	base    int64
+	index   int64
+	phase   int
+	in      uint64
+	out     uint64
+}
+
+// NewResampler creates a Resampler converting from sample rate from to sample rate to.
+func NewResampler(from, to int) *Resampler {
+	g := gcd(from, to)
+	r := &Resampler{up: to / g, down: from / g}
+	r.cutoff = rolloff
+
+	if r.up < r.down {
+		r.cutoff *= float64(r.up) / float64(r.down)
+	}
+
+	r.half = int(math.Ceil(zeroCrossings / r.cutoff))
+	r.buf = make([]float64, r.half-1)
+	r.base = -int64(r.half - 1)
+
+	if r.up*2*r.half <= maxBankSize {
+		r.bank = make([][]float64, r.up)
+
+		for p := range r.bank {
+			r.bank[p] = r.coefficients(p, make([]float64, 2*r.half))
+		}
+	} else {
+		r.scratch = make([]float64, 2*r.half)
+	}
+
+	return r
+}
+
+// OutputLength returns the number of output samples for provided number of input samples.
+func (r *Resampler) OutputLength(n uint64) uint64 {
+	return (n*uint64(r.up) + uint64(r.down) - 1) / uint64(r.down)
+}
+
+// Process appends the output samples available after consuming in to dst.
+func (r *Resampler) Process(in []float64, dst []float64) []float64 {
+	if r.up == r.down {
+		r.in += uint64(len(in))
+		r.out += uint64(len(in))
+		return append(dst, in...)
+	}
+
+	r.buf = append(r.buf, in...)
+	r.in += uint64(len(in))
+
+	return r.emit(dst)
+}
+
+// Flush appends the remaining output samples to dst. Process must not be called after Flush.
+func (r *Resampler) Flush(dst []float64) []float64 {
+	if r.up == r.down {
+		return dst
+	}
+
+	r.buf = append(r.buf, make([]float64, r.half)...)
+
+	return r.emit(dst)
+}
+
+func (r *Resampler) emit(dst []float64) []float64 {
+	last := r.base + int64(len(r.buf)) - 1
+
+	for r.index+int64(r.half) <= last && r.out*uint64(r.down) < r.in*uint64(r.up) {
+		var coefficients []float64
+
+		if r.bank != nil {
+			coefficients = r.bank[r.phase]
+		} else {
+			coefficients = r.coefficients(r.phase, r.scratch)
+		}
+
+		start := int(r.index - int64(r.half) + 1 - r.base)
+		window := r.buf[start : start+len(coefficients)]
+		var y float64
+
+		for j, c := range coefficients {
+			y += window[j] * c
+		}
+
+		dst = append(dst, y)
+		r.out++
+		r.phase += r.down
+		r.index += int64(r.phase / r.up)
+		r.phase %= r.up
+	}
+
+	// drop input samples not required anymore
+	consumed := r.index - int64(r.half) + 1 - r.base
+
+	if consumed > int64(len(r.buf)) {
+		consumed = int64(len(r.buf))
+	}
+
+	if consumed > 0 {
+		r.buf = append(r.buf[:0], r.buf[consumed:]...)
+		r.base += consumed
+	}
+
+	return dst
+}
+
+// coefficients calculates the filter of provided phase.
+// Tap j is applied to input sample index - half + 1 + j of an output sample at index + phase / up.
+func (r *Resampler) coefficients(phase int, dst []float64) []float64 {
+	frac := float64(phase) / float64(r.up)
+
+	for j := range dst {
+		t := float64(r.half-1-j) + frac
+		dst[j] = r.cutoff * sinc(r.cutoff*t) * kaiser(t/float64(r.half), kaiserBeta)
+	}
+
+	return dst
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// kaiser is the Kaiser window for x in range [-1, 1].
+func kaiser(x float64, beta float64) float64 {
+	if x <= -1 || x >= 1 {
+		return 0
+	}
+
+	return besselI0(beta*math.Sqrt(1-x*x)) / besselI0(beta)
+}
+
+// besselI0 is the zeroth order modified Bessel function of the first kind.
+func besselI0(x float64) float64 {
+	sum := 1.
+	term := 1.
+
+	for k := 1; k < 50; k++ {
+		term *= (x / (2 * float64(k))) * (x / (2 * float64(k)))
+		sum += term
+
+		if term < sum*1e-16 {
+			break
+		}
+	}
+
+	return sum
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestResampler(t *testing.T) {
+	rates := [][2]int{{44100, 48000}, {96000, 48000}, {192000, 48000}, {48000, 48000}, {44100, 44101}}
+
+	for _, rate := range rates {
+		from, to := rate[0], rate[1]
+		in := sine(1000, from, from/10)
+		r := NewResampler(from, to)
+		var out []float64
+
+		// process in blocks of varying size
+		for i := 0; i < len(in); i += 999 {
+			end := i + 999
+
+			if end > len(in) {
+				end = len(in)
+			}
+
+			out = r.Process(in[i:end], out)
+		}
+
+		out = r.Flush(out)
+
+		if uint64(len(out)) != r.OutputLength(uint64(len(in))) {
+			t.Fatalf("%d -> %d: output length is %d, want %d", from, to, len(out), r.OutputLength(uint64(len(in))))
+		}
+
+		want := sine(1000, to, len(out))
+
+		// skip filter transients at start and end
+		for i := 100; i < len(out)-100; i++ {
+			if math.Abs(out[i]-want[i]) > 1e-3 {
+				t.Fatalf("%d -> %d: sample %d is %f, want %f", from, to, i, out[i], want[i])
+			}
+		}
+	}
+}
+
+func TestResamplerAntiAliasing(t *testing.T) {
+	in := sine(30000, 96000, 9600)
+	r := NewResampler(96000, 48000)
+	out := r.Flush(r.Process(in, nil))
+
+	for i := 200; i < len(out)-200; i++ {
+		if math.Abs(out[i]) > 1e-3 {
+			t.Fatalf("sample %d is %f, want attenuated alias", i, out[i])
+		}
+	}
+}
+
+func sine(frequency int, sampleRate int, n int) []float64 {
+	samples := make([]float64, n)
+
+	for i := range samples {
+		samples[i] = 0.5 * math.Sin(2*math.Pi*float64(frequency)*float64(i)/float64(sampleRate))
+	}
+
+	return samples
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 )
 
 // IXMLBext for Broadcast Wave Format (BWF) information.
@@ -275,3 +278,80 @@ func DecodeIXMLChunk(data []byte) (*IXML, error) {
 
 	return chunk, err
 }
+
+// readIXML reads and decodes the first 'iXML' chunk of provided container. Nil is returned if not present.
+func readIXML(reader io.ReaderAt, container *Container) (*IXML, error) {
+	headers := container.FindHeaders(IXMLID)
+
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	data, err := ReadChunk(reader, headers[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeIXMLChunk(data)
+}
+
+// updateSamples applies timecode to TIMESTAMP_SAMPLES_SINCE_MIDNIGHT and BWF_TIME_REFERENCE,
+// position to SYNC_POINT and duration to SYNC_POINT_EVENT_DURATION.
+func (c *IXML) updateSamples(timecode func(uint64) uint64, position func(uint64) uint64, duration func(uint64) uint64) {
+	if c.Speed != nil {
+		if value, ok := parseSamples(c.Speed.TimestampSamplesSinceMidnightHi, c.Speed.TimestampSamplesSinceMidnightLo); ok {
+			c.Speed.TimestampSamplesSinceMidnightHi, c.Speed.TimestampSamplesSinceMidnightLo = formatSamples(timecode(value))
+		}
+	}
+
+	if c.Bext != nil {
+		if value, ok := parseSamples(c.Bext.TimeReferenceHigh, c.Bext.TimeReferenceLow); ok {
+			c.Bext.TimeReferenceHigh, c.Bext.TimeReferenceLow = formatSamples(timecode(value))
+		}
+	}
+
+	if c.SyncPointList == nil {
+		return
+	}
+
+	for _, syncPoint := range c.SyncPointList.SyncPoints {
+		if value, ok := parseSamples(syncPoint.SyncPointHigh, syncPoint.SyncPointLow); ok {
+			syncPoint.SyncPointHigh, syncPoint.SyncPointLow = formatSamples(position(value))
+		}
+
+		if value, err := strconv.ParseUint(strings.TrimSpace(syncPoint.SyncPointEventDuration), 10, 64); err == nil {
+			syncPoint.SyncPointEventDuration = strconv.FormatUint(duration(value), 10)
+		}
+	}
+}
+
+// parseSamples parses a sample count split in high and low 32 bit decimal values.
+func parseSamples(high string, low string) (uint64, bool) {
+	if high == "" && low == "" {
+		return 0, false
+	}
+
+	var values [2]uint64
+
+	for i, s := range []string{high, low} {
+		if s == "" {
+			continue
+		}
+
+		value, err := strconv.ParseUint(strings.TrimSpace(s), 10, 32)
+
+		if err != nil {
+			return 0, false
+		}
+
+		values[i] = value
+	}
+
+	return values[0]<<32 | values[1], true
+}
+
+// formatSamples formats a sample count to high and low 32 bit decimal values.
+func formatSamples(value uint64) (string, string) {
+	return strconv.FormatUint(value>>32, 10), strconv.FormatUint(value&0xFFFFFFFF, 10)
+}
@@ -0,0 +1,154 @@
+package chunk
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// LevelStats are level statistics of sampled sound. Values are relative to full scale (1.0).
+type LevelStats struct {
+	// Peak is the max. absolute sample value.
+	Peak float64
+	// PeakPosition is the frame of the first sample with peak value.
+	PeakPosition uint64
+	// RMS is the root mean square of all samples.
+	RMS float64
+	// DCOffset is the mean of all samples.
+	DCOffset float64
+	// Clipped is the number of samples at full scale.
+	Clipped uint64
+	// Samples is the number of analyzed samples.
+	Samples uint64
+}
+
+// PeakDB is the peak level in dBFS.
+func (s *LevelStats) PeakDB() float64 {
+	return 20 * math.Log10(s.Peak)
+}
+
+// RMSDB is the RMS level in dBFS.
+func (s *LevelStats) RMSDB() float64 {
+	return 20 * math.Log10(s.RMS)
+}
+
+// String returns string represensation of level statistics.
+func (s *LevelStats) String() string {
+	return fmt.Sprintf("Peak: %.2f dBFS (frame %d)\nRMS: %.2f dBFS\nDC offset: %f\nClipped samples: %d", s.PeakDB(), s.PeakPosition, s.RMSDB(), s.DCOffset, s.Clipped)
+}
+
+// Levels are level statistics for each channel and over all channels.
+type Levels struct {
+	Overall  *LevelStats
+	Channels []*LevelStats
+}
+
+// String returns string represensation of levels.
+func (l *Levels) String() string {
+	s := fmt.Sprintf("Overall:\n%s", l.Overall)
+
+	for i, c := range l.Channels {
+		s += fmt.Sprintf("\nChannel %d:\n%s", i+1, c)
+	}
+
+	return s
+}
+
+// AnalyzeLevels calculates peak, RMS, DC offset and number of clipped samples of the sound data of provided container.
+// Sound data is read in blocks, so files of any size can be analyzed.
+func AnalyzeLevels(reader io.ReaderAt, container *Container) (*Levels, error) {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = fr.validateSamples()
+
+	if err != nil {
+		return nil, err
+	}
+
+	channels := fr.Channels()
+	sums := make([]float64, channels)
+	squares := make([]float64, channels)
+	levels := &Levels{Overall: &LevelStats{}, Channels: make([]*LevelStats, channels)}
+
+	for c := range levels.Channels {
+		levels.Channels[c] = &LevelStats{}
+	}
+
+	// integer samples clip at the max. value of their valid bits, float samples at 1.0
+	clip := 1.
+
+	if fr.Format() == wavePCM {
+		clip -= 1 / math.Ldexp(1, fr.significantBits()-1)
+	}
+
+	samples := make([]float64, 0)
+	var pos uint64
+
+	err = readFrameBlocks(fr, 0, fr.NumFrames(), func(block []byte, frames int) error {
+		if cap(samples) < frames*channels {
+			samples = make([]float64, frames*channels)
+		}
+
+		samples = samples[:frames*channels]
+		fr.decodeSamples(block, samples)
+
+		for i, value := range samples {
+			c := i % channels
+			stats := levels.Channels[c]
+			abs := math.Abs(value)
+
+			if abs > stats.Peak {
+				stats.Peak = abs
+				stats.PeakPosition = pos + uint64(i/channels)
+			}
+
+			if abs >= clip {
+				stats.Clipped++
+			}
+
+			sums[c] += value
+			squares[c] += value * value
+		}
+
+		pos += uint64(frames)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var sum, square float64
+	overall := levels.Overall
+
+	for c, stats := range levels.Channels {
+		stats.Samples = pos
+
+		if pos > 0 {
+			stats.DCOffset = sums[c] / float64(pos)
+			stats.RMS = math.Sqrt(squares[c] / float64(pos))
+		}
+
+		if stats.Peak > overall.Peak {
+			overall.Peak = stats.Peak
+			overall.PeakPosition = stats.PeakPosition
+		}
+
+		overall.Clipped += stats.Clipped
+		overall.Samples += stats.Samples
+		sum += sums[c]
+		square += squares[c]
+	}
+
+	if overall.Samples > 0 {
+		overall.DCOffset = sum / float64(overall.Samples)
+		overall.RMS = math.Sqrt(square / float64(overall.Samples))
+	}
+
+	return levels, nil
+}
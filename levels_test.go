@@ -0,0 +1,77 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestAnalyzeLevels(t *testing.T) {
+	frames := 4410
+	data := createTestSine(0.5, 100, 44100, frames, 2)
+
+	// channel 2: DC offset 0.25 and a clipped sample at frame 1000
+	for f := 0; f < frames; f++ {
+		binary.LittleEndian.PutUint16(data[f*4+2:], uint16(int16(8192)))
+	}
+
+	binary.LittleEndian.PutUint16(data[1000*4+2:], uint16(int16(math.MaxInt16)))
+
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	levels, err := AnalyzeLevels(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(levels.Channels), 2, "channels length")
+
+	sine := levels.Channels[0]
+
+	assertEqual(t, math.Abs(sine.Peak-0.5) < 1e-4, true, "Peak")
+	assertEqual(t, math.Abs(sine.RMS-0.5/math.Sqrt2) < 1e-3, true, "RMS")
+	assertEqual(t, math.Abs(sine.DCOffset) < 1e-4, true, "DCOffset")
+	assertEqual(t, sine.Clipped, uint64(0), "Clipped")
+	assertEqual(t, sine.Samples, uint64(frames), "Samples")
+
+	dc := levels.Channels[1]
+
+	assertEqual(t, math.Abs(dc.DCOffset-0.25) < 1e-3, true, "DCOffset")
+	assertEqual(t, dc.Clipped, uint64(1), "Clipped")
+	assertEqual(t, dc.PeakPosition, uint64(1000), "PeakPosition")
+	assertEqual(t, math.Abs(dc.PeakDB()) < 1e-3, true, "PeakDB")
+
+	assertEqual(t, levels.Overall.Peak, dc.Peak, "overall Peak")
+	assertEqual(t, levels.Overall.Clipped, uint64(1), "overall Clipped")
+	assertEqual(t, levels.Overall.Samples, uint64(2*frames), "overall Samples")
+}
+
+func TestAnalyzeLevelsValidBits(t *testing.T) {
+	// WAVE_FORMAT_EXTENSIBLE with 24 valid bits in 32 bit containers and sub format PCM
+	fmtData := make([]byte, 40)
+	binary.LittleEndian.PutUint16(fmtData[0:], 0xFFFE)
+	binary.LittleEndian.PutUint16(fmtData[2:], 1)
+	binary.LittleEndian.PutUint32(fmtData[4:], 48000)
+	binary.LittleEndian.PutUint32(fmtData[8:], 192000)
+	binary.LittleEndian.PutUint16(fmtData[12:], 4)
+	binary.LittleEndian.PutUint16(fmtData[14:], 32)
+	binary.LittleEndian.PutUint16(fmtData[16:], 22)
+	binary.LittleEndian.PutUint16(fmtData[18:], 24)
+	binary.LittleEndian.PutUint16(fmtData[24:], 1)
+
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data[0:], 0x7FFFFF00)
+	binary.LittleEndian.PutUint32(data[4:], 0x40000000)
+	binary.LittleEndian.PutUint32(data[8:], 0x80000000)
+
+	chunks := append(EncodeChunkHeader(CreateFourCC(FMTID), uint32(len(fmtData)), binary.LittleEndian).Bytes(), fmtData...)
+	chunks = append(chunks, EncodeChunkHeader(CreateFourCC(DATAID), uint32(len(data)), binary.LittleEndian).Bytes()...)
+	chunks = append(chunks, data...)
+	header := EncodeContainerHeader(CreateFourCC("RIFF"), uint32(len(chunks))+FormatSizeBytes, CreateFourCC("WAVE"), binary.LittleEndian)
+	reader := bytes.NewReader(append(header.Bytes(), chunks...))
+	container, _ := ReadRiff("test", reader)
+	levels, err := AnalyzeLevels(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, levels.Channels[0].Clipped, uint64(2), "Clipped")
+}
@@ -0,0 +1,249 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// LevlFormatUint8 is the peak value format of 8 bit unsigned values.
+	LevlFormatUint8 uint32 = 1
+	// LevlFormatUint16 is the peak value format of 16 bit unsigned values.
+	LevlFormatUint16 uint32 = 2
+	// LevlPeakOfPeaksUnknown is the position of the peak of peaks if unknown.
+	LevlPeakOfPeaksUnknown uint32 = 0xFFFFFFFF
+	// levlHeaderSize is the byte size of the levl chunk including chunk header up to the peak data.
+	levlHeaderSize uint32 = 128
+)
+
+// Levl is Broadcast Wave Format (BWF) peak envelope chunk 'levl' as specified in EBU Tech 3285 Supplement 3.
+// It contains the peak values of blocks of sample frames for each channel to draw a waveform overview.
+type Levl struct {
+	*Header
+	version        uint32
+	format         uint32
+	pointsPerValue uint32
+	blockSize      uint32
+	peakChannels   uint32
+	numPeakFrames  uint32
+	posPeakOfPeaks uint32
+	offsetToPeaks  uint32
+	timestamp      [28]byte
+	reserved       [60]byte
+	peaks          []byte
+}
+
+// Version is the version of the peak envelope chunk.
+func (l *Levl) Version() int {
+	return int(l.version)
+}
+
+// Format is the format of a peak value: 1 for 8 bit, 2 for 16 bit unsigned values.
+func (l *Levl) Format() uint32 {
+	return l.format
+}
+
+// PointsPerValue is 1 if only the positive peak is stored, 2 if positive and negative peak are stored.
+func (l *Levl) PointsPerValue() int {
+	return int(l.pointsPerValue)
+}
+
+// BlockSize is the number of sample frames of a peak frame.
+func (l *Levl) BlockSize() int {
+	return int(l.blockSize)
+}
+
+// PeakChannels is the number of channels.
+func (l *Levl) PeakChannels() int {
+	return int(l.peakChannels)
+}
+
+// NumPeakFrames is the number of peak frames.
+func (l *Levl) NumPeakFrames() int {
+	return int(l.numPeakFrames)
+}
+
+// PosPeakOfPeaks is the position of the sample frame with the max. peak value, LevlPeakOfPeaksUnknown if unknown.
+func (l *Levl) PosPeakOfPeaks() uint32 {
+	return l.posPeakOfPeaks
+}
+
+// OffsetToPeaks is the byte offset of the peak data from the start of the chunk.
+func (l *Levl) OffsetToPeaks() int {
+	return int(l.offsetToPeaks)
+}
+
+// Timestamp is the time of creation of the peak data.
+// 23 characters YYYY:MM:DD:hh:mm:ss:uuu
+func (l *Levl) Timestamp() string {
+	return nullTermToString(l.timestamp[:])
+}
+
+// SetTimestamp sets the time of creation of the peak data.
+func (l *Levl) SetTimestamp(value time.Time) {
+	timestamp := fmt.Sprintf("%04d:%02d:%02d:%02d:%02d:%02d:%03d", value.Year(), value.Month(), value.Day(),
+		value.Hour(), value.Minute(), value.Second(), value.Nanosecond()/int(time.Millisecond))
+	l.timestamp = [28]byte{}
+	copy(l.timestamp[:], timestamp)
+}
+
+// Peaks returns the peak values of all peak frames.
+// Values of a peak frame are interleaved by channel, for each channel the positive peak is followed by
+// the absolute value of the negative peak if PointsPerValue is 2.
+func (l *Levl) Peaks() []uint16 {
+	if l.format == LevlFormatUint8 {
+		peaks := make([]uint16, len(l.peaks))
+
+		for i, value := range l.peaks {
+			peaks[i] = uint16(value)
+		}
+
+		return peaks
+	}
+
+	peaks := make([]uint16, len(l.peaks)/2)
+
+	for i := range peaks {
+		peaks[i] = binary.LittleEndian.Uint16(l.peaks[2*i:])
+	}
+
+	return peaks
+}
+
+// MaxPeak is the max. peak value of the format, 255 for 8 bit and 65535 for 16 bit.
+func (l *Levl) MaxPeak() uint16 {
+	if l.format == LevlFormatUint8 {
+		return 0xFF
+	}
+
+	return 0xFFFF
+}
+
+// String returns string represensation of chunk.
+func (l *Levl) String() string {
+	return fmt.Sprintf("Version: %d\nFormat: %d\nPoints per value: %d\nBlock size: %d\nPeak channels: %d\nPeak frames: %d\nPosition peak of peaks: %d\nOffset to peaks: %d\nTimestamp: %s",
+		l.Version(), l.Format(), l.PointsPerValue(), l.BlockSize(), l.PeakChannels(), l.NumPeakFrames(), l.PosPeakOfPeaks(), l.OffsetToPeaks(), l.Timestamp())
+}
+
+// Bytes converts Levl to byte array. A new Header with id 'levl' is created.
+//
+// Header size is set to real data size. A minimum amount of 128 bytes is returned.
+// chunk header - 8 bytes
+// version - 4 bytes
+// format - 4 bytes
+// points per value - 4 bytes
+// block size - 4 bytes
+// peak channels - 4 bytes
+// peak frames - 4 bytes
+// position peak of peaks - 4 bytes
+// offset to peaks - 4 bytes
+// timestamp - 28 bytes
+// reserved - 60 bytes
+// peak data - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (l *Levl) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	data := make([]byte, levlHeaderSize-HeaderSizeBytes)
+	byteOrder.PutUint32(data[0:4], l.version)
+	byteOrder.PutUint32(data[4:8], l.format)
+	byteOrder.PutUint32(data[8:12], l.pointsPerValue)
+	byteOrder.PutUint32(data[12:16], l.blockSize)
+	byteOrder.PutUint32(data[16:20], l.peakChannels)
+	byteOrder.PutUint32(data[20:24], l.numPeakFrames)
+	byteOrder.PutUint32(data[24:28], l.posPeakOfPeaks)
+	byteOrder.PutUint32(data[28:32], levlHeaderSize)
+	copy(data[32:60], l.timestamp[:])
+	copy(data[60:120], l.reserved[:])
+	data = append(data, l.peaks...)
+	dataSize := len(data)
+	header := EncodeChunkHeader(CreateFourCC(LEVLID), uint32(dataSize), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeLevlChunk returns encoded chunk 'levl' by provided parameters.
+// Peak values are ordered as returned by Peaks and must not exceed the max. value of format.
+func EncodeLevlChunk(format uint32, pointsPerValue int, blockSize int, channels int, posPeakOfPeaks uint32, timestamp time.Time, peaks []uint16) (*Levl, error) {
+	if format != LevlFormatUint8 && format != LevlFormatUint16 {
+		msg := fmt.Sprintf("invalid peak format %d", format)
+		return nil, errors.New(msg)
+	}
+
+	if pointsPerValue < 1 || pointsPerValue > 2 || channels < 1 || len(peaks)%(pointsPerValue*channels) != 0 {
+		msg := fmt.Sprintf("%d peak values do not match %d points per value of %d channels", len(peaks), pointsPerValue, channels)
+		return nil, errors.New(msg)
+	}
+
+	l := &Levl{version: 1, format: format, pointsPerValue: uint32(pointsPerValue), blockSize: uint32(blockSize),
+		peakChannels: uint32(channels), numPeakFrames: uint32(len(peaks) / (pointsPerValue * channels)),
+		posPeakOfPeaks: posPeakOfPeaks, offsetToPeaks: levlHeaderSize}
+	l.SetTimestamp(timestamp)
+
+	if format == LevlFormatUint8 {
+		l.peaks = make([]byte, len(peaks))
+
+		for i, value := range peaks {
+			l.peaks[i] = uint8(value)
+		}
+	} else {
+		l.peaks = make([]byte, 2*len(peaks))
+
+		for i, value := range peaks {
+			binary.LittleEndian.PutUint16(l.peaks[2*i:], value)
+		}
+	}
+
+	l.Header = EncodeChunkHeader(CreateFourCC(LEVLID), levlHeaderSize-HeaderSizeBytes+uint32(len(l.peaks)), binary.LittleEndian)
+
+	return l, nil
+}
+
+// DecodeLevlChunk decodes provided byte array to Levl.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// data - 120 bytes
+// peak data - not restricted amount of bytes
+func DecodeLevlChunk(data []byte) (*Levl, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	l := &Levl{}
+	byteOrder := binary.LittleEndian
+	l.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	fields := []interface{}{&l.version, &l.format, &l.pointsPerValue, &l.blockSize, &l.peakChannels,
+		&l.numPeakFrames, &l.posPeakOfPeaks, &l.offsetToPeaks, &l.timestamp, &l.reserved}
+
+	for _, f := range fields {
+		err := binary.Read(buf, byteOrder, f)
+
+		if err != nil {
+			return l, err
+		}
+	}
+
+	start := int(l.offsetToPeaks)
+	end := int(HeaderSizeBytes + l.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	if start < int(levlHeaderSize) || start > end {
+		start = int(levlHeaderSize)
+	}
+
+	if start <= end {
+		l.peaks = append([]byte{}, data[start:end]...)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,54 @@
+package chunk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncodeLevlChunk(t *testing.T) {
+	timestamp := time.Date(2021, 3, 4, 5, 6, 7, 890000000, time.UTC)
+	chunk, err := EncodeLevlChunk(LevlFormatUint16, 2, 256, 2, 1000, timestamp, []uint16{1, 2, 3, 4, 5, 6, 7, 8})
+
+	assertNil(t, err, "err")
+	assertEqual(t, chunk.ID(), LEVLID, "ID")
+	assertEqual(t, chunk.Size(), uint32(120+16), "Size")
+	assertEqual(t, chunk.Version(), 1, "Version")
+	assertEqual(t, chunk.PointsPerValue(), 2, "PointsPerValue")
+	assertEqual(t, chunk.BlockSize(), 256, "BlockSize")
+	assertEqual(t, chunk.PeakChannels(), 2, "PeakChannels")
+	assertEqual(t, chunk.NumPeakFrames(), 2, "NumPeakFrames")
+	assertEqual(t, chunk.PosPeakOfPeaks(), uint32(1000), "PosPeakOfPeaks")
+	assertEqual(t, chunk.OffsetToPeaks(), 128, "OffsetToPeaks")
+	assertEqual(t, chunk.Timestamp(), "2021:03:04:05:06:07:890", "Timestamp")
+	assertEqual(t, chunk.Peaks()[7], uint16(8), "Peaks")
+
+	_, err = EncodeLevlChunk(3, 2, 256, 2, 0, timestamp, nil)
+
+	assertNotNil(t, err, "err with invalid format")
+
+	_, err = EncodeLevlChunk(LevlFormatUint8, 2, 256, 2, 0, timestamp, []uint16{1, 2, 3})
+
+	assertNotNil(t, err, "err with incomplete peak frame")
+}
+
+func TestDecodeLevlChunk(t *testing.T) {
+	chunk, _ := EncodeLevlChunk(LevlFormatUint8, 1, 512, 1, LevlPeakOfPeaksUnknown, time.Now(), []uint16{10, 20, 255})
+	data := chunk.Bytes()
+
+	assertEqual(t, len(data), 128+3+1, "Bytes length")
+
+	decoded, err := DecodeLevlChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Size(), uint32(123), "Size")
+	assertEqual(t, decoded.Format(), LevlFormatUint8, "Format")
+	assertEqual(t, decoded.BlockSize(), 512, "BlockSize")
+	assertEqual(t, decoded.NumPeakFrames(), 3, "NumPeakFrames")
+	assertEqual(t, decoded.PosPeakOfPeaks(), LevlPeakOfPeaksUnknown, "PosPeakOfPeaks")
+	assertEqual(t, len(decoded.Peaks()), 3, "Peaks length")
+	assertEqual(t, decoded.Peaks()[2], uint16(255), "Peaks")
+
+	_, err = DecodeLevlChunk(data[:HeaderSizeBytes-1])
+
+	assertNotNil(t, err, "err with short data")
+}
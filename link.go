@@ -0,0 +1,184 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// LinkFileActual is the type of the file containing the chunk 'link'.
+	LinkFileActual = "actual"
+	// LinkFileOther is the type of the other files of the file set.
+	LinkFileOther = "other"
+)
+
+// LinkFile is a file of a multi-file recording listed in chunk 'link'.
+type LinkFile struct {
+	// Type is LinkFileActual or LinkFileOther.
+	Type string `xml:"type,attr"`
+	// Number is the 1 based sequence number of the file.
+	Number int `xml:"FILENUMBER"`
+	// Name is the file name without directory.
+	Name string `xml:"FILENAME"`
+}
+
+// Link is Broadcast Wave Format (BWF) chunk 'link' as specified in EBU Tech 3285 Supplement 5.
+// It lists the files of a recording spanning several files as XML document with root element LINK.
+type Link struct {
+	*Header
+	Files []*LinkFile `xml:"FILE"`
+	// FileSetID identifies the file set.
+	FileSetID string `xml:"ID,omitempty"`
+}
+
+// Actual returns the file containing the chunk, nil if not listed.
+func (l *Link) Actual() *LinkFile {
+	for _, f := range l.Files {
+		if f.Type == LinkFileActual {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// SortedFiles returns the files ordered by sequence number.
+func (l *Link) SortedFiles() []*LinkFile {
+	files := append([]*LinkFile{}, l.Files...)
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Number < files[j].Number })
+
+	return files
+}
+
+// String returns string represensation of chunk.
+func (l *Link) String() string {
+	s := fmt.Sprintf("File set ID: %s", l.FileSetID)
+
+	for _, f := range l.SortedFiles() {
+		s += fmt.Sprintf("\n%d: %s (%s)", f.Number, f.Name, f.Type)
+	}
+
+	return s
+}
+
+// Bytes converts Link to byte array. A new Header with id 'link' is created.
+//
+// Header size is set to real data size. A minimum amount of 8 bytes is returned.
+// chunk header - 8 bytes
+// XML - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (l *Link) Bytes() []byte {
+	data := l.xml()
+	header := EncodeChunkHeader(CreateFourCC(LINKID), uint32(len(data)), binary.LittleEndian)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+func (l *Link) xml() []byte {
+	tmp := struct {
+		XMLName struct{}    `xml:"LINK"`
+		Files   []*LinkFile `xml:"FILE"`
+		ID      string      `xml:"ID,omitempty"`
+	}{Files: l.Files, ID: l.FileSetID}
+
+	// marshalling of strings and numbers does not fail
+	data, _ := xml.MarshalIndent(tmp, "", "  ")
+
+	return append([]byte(xml.Header), data...)
+}
+
+// EncodeLinkChunk returns encoded chunk 'link' listing provided files of the file set with provided file set id.
+func EncodeLinkChunk(id string, files []*LinkFile) *Link {
+	l := &Link{Files: files, FileSetID: id}
+	l.Header = EncodeChunkHeader(CreateFourCC(LINKID), uint32(len(l.xml())), binary.LittleEndian)
+
+	return l
+}
+
+// DecodeLinkChunk decodes provided byte array to Link.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// XML - not restricted amount of bytes
+func DecodeLinkChunk(data []byte) (*Link, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	header := decodeChunkHeader(data[:HeaderSizeBytes], 0, binary.LittleEndian)
+	end := int(HeaderSizeBytes + header.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	l := &Link{Header: header}
+	err := xml.Unmarshal([]byte(nullTermToString(data[HeaderSizeBytes:end])), l)
+
+	return l, err
+}
+
+// ReadLink reads and decodes the first chunk 'link' of provided container.
+func ReadLink(reader io.ReaderAt, container *Container) (*Link, error) {
+	header, err := findHeader(container, LINKID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ReadChunk(reader, header)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeLinkChunk(data)
+}
+
+// LinkedFiles returns the paths of all files of the multi-file recording of the BWF at provided path,
+// ordered by sequence number. The files are resolved in the directory of path, an error is returned
+// if a file of the set is missing.
+func LinkedFiles(path string) ([]string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+	container, err := ReadRiff(file.Name(), file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := ReadLink(file, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	files := link.SortedFiles()
+	paths := make([]string, len(files))
+
+	for i, f := range files {
+		paths[i] = filepath.Join(dir, filepath.Base(f.Name))
+		_, err = os.Stat(paths[i])
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,65 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLink(t *testing.T) {
+	files := []*LinkFile{
+		{Type: LinkFileOther, Number: 2, Name: "rec_2.wav"},
+		{Type: LinkFileActual, Number: 1, Name: "rec_1.wav"},
+	}
+	chunk := EncodeLinkChunk("set-1", files)
+
+	assertEqual(t, chunk.ID(), LINKID, "ID")
+
+	data := chunk.Bytes()
+
+	assertEqual(t, binary.LittleEndian.Uint32(data[4:8]), chunk.Size(), "Size")
+	assertEqual(t, bytes.Contains(data, []byte("<FILE type=\"actual\">")), true, "FILE element")
+
+	decoded, err := DecodeLinkChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.FileSetID, "set-1", "FileSetID")
+	assertEqual(t, decoded.Actual().Name, "rec_1.wav", "Actual")
+	assertEqual(t, decoded.SortedFiles()[1].Name, "rec_2.wav", "SortedFiles")
+
+	_, err = DecodeLinkChunk(data[:HeaderSizeBytes-1])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestLinkedFiles(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{"rec_1.wav", "rec_2.wav", "rec_3.wav"}
+	format := EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16)
+
+	for i, name := range names {
+		files := make([]*LinkFile, len(names))
+
+		for j := range names {
+			files[j] = &LinkFile{Type: LinkFileOther, Number: len(names) - j, Name: names[len(names)-1-j]}
+		}
+
+		files[len(names)-1-i].Type = LinkFileActual
+		riff := append(createTestRiff(format, make([]byte, 4)), EncodeLinkChunk("set", files).Bytes()...)
+		binary.LittleEndian.PutUint32(riff[4:8], uint32(len(riff)-8))
+		os.WriteFile(filepath.Join(dir, name), riff, 0644)
+	}
+
+	paths, err := LinkedFiles(filepath.Join(dir, "rec_2.wav"))
+
+	assertNil(t, err, "err")
+	assertEqual(t, strings.Join(paths, ","), strings.Join([]string{filepath.Join(dir, names[0]), filepath.Join(dir, names[1]), filepath.Join(dir, names[2])}, ","), "paths")
+
+	os.Remove(filepath.Join(dir, "rec_3.wav"))
+	_, err = LinkedFiles(filepath.Join(dir, "rec_1.wav"))
+
+	assertNotNil(t, err, "err with missing file")
+}
@@ -0,0 +1,360 @@
+package chunk
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/metawav/chunk/internal"
+)
+
+const (
+	// absolute gate threshold in LUFS
+	loudnessAbsoluteGate = -70.
+	// relative gate for integrated loudness in LU
+	loudnessRelativeGate = -10.
+	// relative gate for loudness range in LU
+	loudnessRangeRelativeGate = -20.
+	// sub blocks per second, all measurement windows are multiples of a sub block
+	loudnessSubBlocks = 10
+	// bext value of loudness fields not measured
+	bextLoudnessUnset = 0x7FFF
+)
+
+// Loudness is the result of a loudness measurement as specified in ITU-R BS.1770-4 and EBU R 128.
+type Loudness struct {
+	// Integrated is the gated loudness of the whole programme in LUFS.
+	Integrated float64
+	// Range is the loudness range (LRA) in LU as specified in EBU Tech 3342.
+	Range float64
+	// MaxTruePeak is the max. true peak level in dBTP.
+	MaxTruePeak float64
+	// MaxMomentary is the max. momentary loudness (400 ms window) in LUFS.
+	MaxMomentary float64
+	// MaxShortTerm is the max. short-term loudness (3 s window) in LUFS.
+	MaxShortTerm float64
+}
+
+// String returns string represensation of loudness.
+func (l *Loudness) String() string {
+	return fmt.Sprintf("Integrated: %.1f LUFS\nRange: %.1f LU\nMax True Peak: %.1f dBTP\nMax Momentary: %.1f LUFS\nMax Short Term: %.1f LUFS",
+		l.Integrated, l.Range, l.MaxTruePeak, l.MaxMomentary, l.MaxShortTerm)
+}
+
+// Apply writes the loudness values to the loudness fields of provided bext chunk and to the
+// LOUDNESS block of provided iXML chunk. Bext version is set to 2 if lower.
+// Both chunks are optional and can be nil.
+//
+// Bext values are stored as 100 times the value rounded to an integer, values not measurable
+// (e.g. integrated loudness of silence) as 0x7FFF.
+func (l *Loudness) Apply(b *Bext, c *IXML) {
+	if b != nil {
+		if b.Version() < 2 {
+			b.SetVersion(2)
+		}
+
+		b.SetLoudnessValue(bextLoudness(l.Integrated))
+		b.SetLoudnessRange(bextLoudness(l.Range))
+		b.SetMaxTruePeakLevel(bextLoudness(l.MaxTruePeak))
+		b.SetMaxMomentaryLoudness(bextLoudness(l.MaxMomentary))
+		b.SetMaxShortTermLoudness(bextLoudness(l.MaxShortTerm))
+	}
+
+	if c != nil {
+		c.Loudness = &IXMLLoudness{
+			LoudnessValue:        ixmlLoudness(l.Integrated),
+			LoudnessRange:        ixmlLoudness(l.Range),
+			MaxTruePeakLevel:     ixmlLoudness(l.MaxTruePeak),
+			MaxMomentaryLoudness: ixmlLoudness(l.MaxMomentary),
+			MaxShortTermLoudness: ixmlLoudness(l.MaxShortTerm),
+		}
+	}
+}
+
+func bextLoudness(value float64) uint16 {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return bextLoudnessUnset
+	}
+
+	return uint16(int16(math.Round(value * 100)))
+}
+
+func ixmlLoudness(value float64) string {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return ""
+	}
+
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// MeasureLoudness measures integrated loudness, loudness range, max. true peak level and max. momentary and
+// short-term loudness of the sound data of provided container.
+//
+// Channels are weighted equally, except for 6 channels which are treated as 5.1 (L, R, C, LFE, Ls, Rs):
+// LFE is excluded and surround channels are weighted by +1.5 dB.
+// True peak is measured by 4x oversampling (2x for 96 kHz, none for 192 kHz and above).
+func MeasureLoudness(reader io.ReaderAt, container *Container) (*Loudness, error) {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = fr.validateSamples()
+
+	if err != nil {
+		return nil, err
+	}
+
+	channels := fr.Channels()
+	sampleRate := fr.SampleRate()
+
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("invalid sample rate %d", sampleRate)
+	}
+
+	weights := loudnessWeights(channels)
+	filters := make([][2]*internal.Biquad, channels)
+	oversampling := 4
+
+	if sampleRate >= 192000 {
+		oversampling = 1
+	} else if sampleRate >= 96000 {
+		oversampling = 2
+	}
+
+	upsamplers := make([]*internal.Resampler, channels)
+
+	for c := range filters {
+		shelf, highPass := internal.KWeighting(sampleRate)
+		filters[c] = [2]*internal.Biquad{shelf, highPass}
+		upsamplers[c] = internal.NewResampler(sampleRate, sampleRate*oversampling)
+	}
+
+	// weighted energy of sub blocks of 100 ms
+	subBlockSize := int(math.Round(float64(sampleRate) / loudnessSubBlocks))
+	var subBlocks []float64
+	var energy float64
+	var subBlockPos int
+	var truePeak float64
+	samples := make([]float64, 0)
+	channelSamples := make([]float64, 0)
+	var oversampled []float64
+
+	err = readFrameBlocks(fr, 0, fr.NumFrames(), func(block []byte, frames int) error {
+		if cap(samples) < frames*channels {
+			samples = make([]float64, frames*channels)
+			channelSamples = make([]float64, frames)
+		}
+
+		samples = samples[:frames*channels]
+		channelSamples = channelSamples[:frames]
+		fr.decodeSamples(block, samples)
+
+		for c := 0; c < channels; c++ {
+			for f := 0; f < frames; f++ {
+				channelSamples[f] = samples[f*channels+c]
+			}
+
+			oversampled = upsamplers[c].Process(channelSamples, oversampled[:0])
+			truePeak = math.Max(truePeak, maxAbs(oversampled))
+			truePeak = math.Max(truePeak, maxAbs(channelSamples))
+		}
+
+		for f := 0; f < frames; f++ {
+			for c := 0; c < channels; c++ {
+				value := filters[c][1].Process(filters[c][0].Process(samples[f*channels+c]))
+				energy += weights[c] * value * value
+			}
+
+			subBlockPos++
+
+			if subBlockPos == subBlockSize {
+				subBlocks = append(subBlocks, energy)
+				energy = 0
+				subBlockPos = 0
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for c := range upsamplers {
+		oversampled = upsamplers[c].Flush(oversampled[:0])
+		truePeak = math.Max(truePeak, maxAbs(oversampled))
+	}
+
+	momentary := windowLoudness(subBlocks, 4, subBlockSize)
+	shortTerm := windowLoudness(subBlocks, 30, subBlockSize)
+	l := &Loudness{
+		Integrated:   gatedLoudness(momentary, loudnessRelativeGate),
+		Range:        loudnessRange(shortTerm),
+		MaxTruePeak:  20 * math.Log10(truePeak),
+		MaxMomentary: maxLoudness(momentary),
+		MaxShortTerm: maxLoudness(shortTerm),
+	}
+
+	return l, nil
+}
+
+// WriteLoudness measures the loudness of provided container (see MeasureLoudness) and copies the container to ws
+// with the loudness applied to chunks 'bext' and 'iXML' (see Loudness.Apply).
+// Chunk 'iXML' is only updated if present, chunk 'bext' is added if missing.
+func WriteLoudness(ws io.WriteSeeker, reader io.ReaderAt, container *Container) (*Loudness, error) {
+	l, err := MeasureLoudness(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := readBext(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if b == nil {
+		b = &Bext{}
+	}
+
+	c, err := readIXML(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	l.Apply(b, c)
+	chunks := [][]byte{b.Bytes()}
+
+	if c != nil {
+		data, err := c.Bytes()
+
+		if err != nil {
+			return nil, err
+		}
+
+		chunks = append(chunks, data)
+	}
+
+	return l, ReplaceChunks(ws, reader, container, chunks...)
+}
+
+// loudnessWeights returns the channel weights of ITU-R BS.1770.
+func loudnessWeights(channels int) []float64 {
+	weights := make([]float64, channels)
+
+	for c := range weights {
+		weights[c] = 1
+	}
+
+	if channels == 6 {
+		weights[3] = 0
+		weights[4] = 1.41
+		weights[5] = 1.41
+	}
+
+	return weights
+}
+
+// windowLoudness returns the loudness of each window of provided number of sub blocks, windows are stepped by one sub block.
+func windowLoudness(subBlocks []float64, size int, subBlockSize int) []float64 {
+	var loudness []float64
+	var energy float64
+
+	for i, e := range subBlocks {
+		energy += e
+
+		if i >= size {
+			energy -= subBlocks[i-size]
+		}
+
+		if i >= size-1 {
+			loudness = append(loudness, energyToLoudness(math.Max(energy, 0)/float64(size*subBlockSize)))
+		}
+	}
+
+	return loudness
+}
+
+// gatedLoudness returns the loudness of blocks above the absolute gate and above the relative gate.
+func gatedLoudness(blocks []float64, relativeGate float64) float64 {
+	gated := gate(blocks, loudnessAbsoluteGate)
+	relative := meanLoudness(gated) + relativeGate
+
+	return meanLoudness(gate(gated, relative))
+}
+
+// loudnessRange returns the difference of the 95th and 10th percentile of gated short-term loudness.
+func loudnessRange(shortTerm []float64) float64 {
+	gated := gate(shortTerm, loudnessAbsoluteGate)
+	gated = gate(gated, meanLoudness(gated)+loudnessRangeRelativeGate)
+
+	if len(gated) == 0 {
+		return 0
+	}
+
+	sort.Float64s(gated)
+	percentile := func(p float64) float64 {
+		return gated[int(math.Round(p*float64(len(gated)-1)))]
+	}
+
+	return percentile(0.95) - percentile(0.1)
+}
+
+// gate returns all blocks with a loudness greater than threshold.
+func gate(blocks []float64, threshold float64) []float64 {
+	var gated []float64
+
+	for _, l := range blocks {
+		if l > threshold {
+			gated = append(gated, l)
+		}
+	}
+
+	return gated
+}
+
+// meanLoudness returns the loudness of the mean energy of provided blocks.
+func meanLoudness(blocks []float64) float64 {
+	if len(blocks) == 0 {
+		return math.Inf(-1)
+	}
+
+	var energy float64
+
+	for _, l := range blocks {
+		energy += math.Pow(10, (l+0.691)/10)
+	}
+
+	return energyToLoudness(energy / float64(len(blocks)))
+}
+
+func maxLoudness(blocks []float64) float64 {
+	max := math.Inf(-1)
+
+	for _, l := range blocks {
+		max = math.Max(max, l)
+	}
+
+	return max
+}
+
+func energyToLoudness(energy float64) float64 {
+	return -0.691 + 10*math.Log10(energy)
+}
+
+func maxAbs(values []float64) float64 {
+	var max float64
+
+	for _, v := range values {
+		max = math.Max(max, math.Abs(v))
+	}
+
+	return max
+}
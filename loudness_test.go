@@ -0,0 +1,105 @@
+package chunk
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMeasureLoudness(t *testing.T) {
+	// EBU Tech 3341 test case: stereo sine 1 kHz at -23 dBFS
+	amplitude := math.Pow(10, -23./20)
+	data := createTestSine(amplitude, 1000, 48000, 48000*5, 2)
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 48000, 192000, 4, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	l, err := MeasureLoudness(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, math.Abs(l.Integrated+23) < 0.1, true, "Integrated")
+	assertEqual(t, math.Abs(l.MaxMomentary+23) < 0.1, true, "MaxMomentary")
+	assertEqual(t, math.Abs(l.MaxShortTerm+23) < 0.1, true, "MaxShortTerm")
+	assertEqual(t, math.Abs(l.MaxTruePeak+23) < 0.1, true, "MaxTruePeak")
+	assertEqual(t, l.Range < 0.1, true, "Range")
+
+	riff = createTestRiff(EncodePCMFormatChunk(16, 1, 2, 48000, 192000, 4, 16), make([]byte, 48000*4))
+	reader = bytes.NewReader(riff)
+	container, _ = ReadRiff("test", reader)
+	l, err = MeasureLoudness(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, math.IsInf(l.Integrated, -1), true, "Integrated of silence")
+}
+
+func TestWriteLoudness(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "in.wav")
+	bext := &Bext{}
+	bext.SetDescription("take 1")
+	createTestPolyFile(t, path, EncodePCMFormatChunk(16, 1, 2, 48000, 192000, 4, 16), bext, &IXML{}, createTestSine(math.Pow(10, -23./20), 1000, 48000, 48000*5, 2))
+
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadRiff(in.Name(), in)
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	l, err := WriteLoudness(out, in, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, math.Abs(l.Integrated+23) < 0.1, true, "Integrated")
+
+	out.Seek(0, io.SeekStart)
+	container, _ = ReadRiff(out.Name(), out)
+
+	assertEqual(t, len(container.FindHeaders(BEXTID)), 1, "bext chunks")
+
+	outBext, _ := readBext(out, container)
+
+	assertEqual(t, outBext.Description(), "take 1", "Description")
+	assertEqual(t, outBext.Version(), uint16(2), "Version")
+	assertEqual(t, int16(outBext.LoudnessValue()), int16(bextLoudness(l.Integrated)), "LoudnessValue")
+
+	outIXML, _ := readIXML(out, container)
+
+	assertEqual(t, outIXML.Loudness.LoudnessValue, ixmlLoudness(l.Integrated), "LoudnessValue")
+
+	// bext is added, iXML is not
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 48000, 96000, 2, 16), make([]byte, 48000*2))
+	reader := bytes.NewReader(riff)
+	container, _ = ReadRiff("test", reader)
+	out, _ = os.Create(filepath.Join(dir, "silence.wav"))
+	defer out.Close()
+	_, err = WriteLoudness(out, reader, container)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, io.SeekStart)
+	container, _ = ReadRiff(out.Name(), out)
+	outBext, _ = readBext(out, container)
+
+	assertNotNil(t, outBext, "bext")
+	assertEqual(t, outBext.LoudnessValue(), uint16(0x7FFF), "LoudnessValue of silence")
+	assertEqual(t, len(container.FindHeaders(IXMLID)), 0, "iXML chunks")
+}
+
+func TestLoudnessApply(t *testing.T) {
+	l := &Loudness{Integrated: -23.004, Range: 5.5, MaxTruePeak: -1.2, MaxMomentary: -18, MaxShortTerm: math.Inf(-1)}
+	b := &Bext{}
+	c := &IXML{}
+	l.Apply(b, c)
+
+	assertEqual(t, b.Version(), uint16(2), "Version")
+	assertEqual(t, int16(b.LoudnessValue()), int16(-2300), "LoudnessValue")
+	assertEqual(t, b.LoudnessRange(), uint16(550), "LoudnessRange")
+	assertEqual(t, int16(b.MaxTruePeakLevel()), int16(-120), "MaxTruePeakLevel")
+	assertEqual(t, int16(b.MaxMomentaryLoudness()), int16(-1800), "MaxMomentaryLoudness")
+	assertEqual(t, b.MaxShortTermLoudness(), uint16(0x7FFF), "MaxShortTermLoudness")
+	assertEqual(t, c.Loudness.LoudnessValue, "-23.00", "LoudnessValue")
+	assertEqual(t, c.Loudness.LoudnessRange, "5.50", "LoudnessRange")
+	assertEqual(t, c.Loudness.MaxShortTermLoudness, "", "MaxShortTermLoudness")
+
+	l.Apply(nil, nil)
+}
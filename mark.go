@@ -0,0 +1,175 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// AIFFMarker is a marker of AIFF marker chunk 'MARK'.
+type AIFFMarker struct {
+	// ID is the unique identifier of the marker, greater than 0.
+	ID int16
+	// Position is the sample frame of the marker, 0 is before the first sample frame.
+	Position uint32
+	// Name is the name of the marker, max. 255 characters.
+	Name string
+}
+
+// String returns string represensation of marker.
+func (m *AIFFMarker) String() string {
+	return fmt.Sprintf("ID: %d Position: %d Name: %s", m.ID, m.Position, m.Name)
+}
+
+// MARK is AIFF / AIFF-C marker chunk 'MARK' containing markers of positions in the sound data.
+type MARK struct {
+	*Header
+	markers []*AIFFMarker
+}
+
+// Markers returns the markers.
+func (m *MARK) Markers() []*AIFFMarker {
+	return m.markers
+}
+
+// SetMarkers sets the markers.
+func (m *MARK) SetMarkers(markers []*AIFFMarker) {
+	m.markers = markers
+}
+
+// Marker returns the marker of provided id, nil if not found.
+func (m *MARK) Marker(id int16) *AIFFMarker {
+	for _, marker := range m.markers {
+		if marker.ID == id {
+			return marker
+		}
+	}
+
+	return nil
+}
+
+// String returns string represensation of chunk.
+func (m *MARK) String() string {
+	lines := make([]string, len(m.markers))
+
+	for i, marker := range m.markers {
+		lines[i] = marker.String()
+	}
+
+	return fmt.Sprintf("Markers: %d\n%s", len(m.markers), strings.Join(lines, "\n"))
+}
+
+// Bytes converts MARK to byte array. A new Header with id 'MARK' is created.
+//
+// Header size is set to real data size. A minimum of 10 bytes is returned.
+// chunk header - 8 bytes
+// number of markers - 2 bytes
+// markers - id (2 bytes), position (4 bytes), name (pascal string padded to even size)
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (m *MARK) Bytes() []byte {
+	byteOrder := binary.BigEndian
+	data := make([]byte, 2)
+	byteOrder.PutUint16(data, uint16(len(m.markers)))
+
+	for _, marker := range m.markers {
+		values := make([]byte, 6)
+		byteOrder.PutUint16(values[0:2], uint16(marker.ID))
+		byteOrder.PutUint32(values[2:6], marker.Position)
+		data = append(data, values...)
+		data = append(data, encodePascalString(marker.Name)...)
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(MARKID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeMARKChunk returns encoded chunk 'MARK' containing provided markers.
+func EncodeMARKChunk(markers []*AIFFMarker) *MARK {
+	m := &MARK{markers: markers}
+	m.Header = decodeChunkHeader(m.Bytes(), 0, binary.BigEndian)
+
+	return m
+}
+
+// DecodeMARKChunk decodes provided byte array to MARK.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// number of markers - 2 bytes
+// markers - not restricted amount of bytes
+func DecodeMARKChunk(data []byte) (*MARK, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	m := &MARK{}
+	byteOrder := binary.BigEndian
+	m.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	var count uint16
+	err := binary.Read(buf, byteOrder, &count)
+
+	if err != nil {
+		return m, err
+	}
+
+	for i := uint16(0); i < count; i++ {
+		marker := &AIFFMarker{}
+		fields := []interface{}{&marker.ID, &marker.Position}
+
+		for _, f := range fields {
+			err := binary.Read(buf, byteOrder, f)
+
+			if err != nil {
+				return m, err
+			}
+		}
+
+		marker.Name, err = decodePascalString(buf)
+
+		if err != nil {
+			return m, err
+		}
+
+		m.markers = append(m.markers, marker)
+	}
+
+	return m, nil
+}
+
+// encodePascalString encodes value as pascal string: count byte followed by max. 255 characters, padded to even size.
+func encodePascalString(value string) []byte {
+	if len(value) > 255 {
+		value = value[:255]
+	}
+
+	data := append([]byte{byte(len(value))}, value...)
+
+	return pad(data)
+}
+
+// decodePascalString reads a pascal string padded to even size.
+func decodePascalString(buf *bytes.Reader) (string, error) {
+	count, err := buf.ReadByte()
+
+	if err != nil {
+		return "", err
+	}
+
+	// count byte and characters are padded to even size
+	value := make([]byte, int(count)+1-int(count)%2)
+	_, err = io.ReadFull(buf, value)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(value[:count]), nil
+}
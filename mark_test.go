@@ -0,0 +1,28 @@
+package chunk
+
+import "testing"
+
+func TestEncodeMARKChunk(t *testing.T) {
+	chunk := EncodeMARKChunk([]*AIFFMarker{{ID: 1, Position: 100, Name: "ab"}, {ID: 2, Position: 200, Name: "abc"}})
+
+	assertEqual(t, chunk.ID(), MARKID, "ID")
+	// count, marker 1 with name padded to even size, marker 2
+	assertEqual(t, chunk.Size(), uint32(2+6+4+6+4), "Size")
+	assertEqual(t, chunk.Marker(2).Position, uint32(200), "Marker")
+	assertNil(t, chunk.Marker(3), "Marker of unknown id")
+}
+
+func TestDecodeMARKChunk(t *testing.T) {
+	data := EncodeMARKChunk([]*AIFFMarker{{ID: 1, Position: 100, Name: "ab"}, {ID: 2, Position: 200, Name: "abc"}, {ID: 3, Position: 300}}).Bytes()
+	chunk, err := DecodeMARKChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(chunk.Markers()), 3, "Markers length")
+	assertEqual(t, *chunk.Markers()[0], AIFFMarker{ID: 1, Position: 100, Name: "ab"}, "Marker")
+	assertEqual(t, *chunk.Markers()[1], AIFFMarker{ID: 2, Position: 200, Name: "abc"}, "Marker")
+	assertEqual(t, *chunk.Markers()[2], AIFFMarker{ID: 3, Position: 300}, "Marker")
+
+	_, err = DecodeMARKChunk(data[:len(data)-3])
+
+	assertNotNil(t, err, "err with incomplete marker")
+}
@@ -0,0 +1,146 @@
+package chunk
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// regionPurpose is the purpose of labeled texts defining a region.
+const regionPurpose = "rgn "
+
+// Marker is a named position in the sound data joined from a cue point of chunk 'cue ' and
+// its label, note and labeled text of chunk 'LIST' of type 'adtl'.
+// A marker with a length greater than 0 is a region.
+type Marker struct {
+	// ID is the cue point id. If 0, a free id is assigned on encoding.
+	ID uint32
+	// Position is the sample frame of the marker.
+	Position uint64
+	// Length is the number of sample frames of a region, 0 for a marker.
+	Length uint64
+	// Name is the text of the label 'labl' or of the labeled text 'ltxt' if no label is present.
+	Name string
+	// Note is the text of the note 'note'.
+	Note string
+}
+
+// IsRegion returns true if the marker has a length.
+func (m *Marker) IsRegion() bool {
+	return m.Length > 0
+}
+
+// String returns string represensation of marker.
+func (m *Marker) String() string {
+	return fmt.Sprintf("ID: %d Position: %d Length: %d Name: %s Note: %s", m.ID, m.Position, m.Length, m.Name, m.Note)
+}
+
+// Markers joins cue points of provided chunk 'cue ' with labels, notes and labeled texts of provided
+// chunk 'LIST' of type 'adtl', which can be nil. Markers are ordered by position.
+func Markers(cue *Cue, adtl *Adtl) []*Marker {
+	if cue == nil {
+		return nil
+	}
+
+	markers := make([]*Marker, len(cue.Points()))
+
+	for i, p := range cue.Points() {
+		m := &Marker{ID: p.ID, Position: uint64(p.Position)}
+
+		if p.DataChunkID == DATAID {
+			m.Position = uint64(p.SampleOffset)
+		}
+
+		if adtl != nil {
+			if t := adtl.LabeledText(p.ID); t != nil {
+				m.Length = uint64(t.SampleLength)
+				m.Name = t.Text
+			}
+
+			if l := adtl.Label(p.ID); l != nil {
+				m.Name = l.Text
+			}
+
+			if n := adtl.Note(p.ID); n != nil {
+				m.Note = n.Text
+			}
+		}
+
+		markers[i] = m
+	}
+
+	sort.SliceStable(markers, func(i, j int) bool {
+		return markers[i].Position < markers[j].Position
+	})
+
+	return markers
+}
+
+// EncodeMarkers returns chunk 'cue ' and chunk 'LIST' of type 'adtl' for provided markers.
+// Markers with id 0 are assigned a free id.
+func EncodeMarkers(markers []*Marker) (*Cue, *Adtl) {
+	used := make(map[uint32]bool)
+
+	for _, m := range markers {
+		used[m.ID] = true
+	}
+
+	nextID := uint32(1)
+	points := make([]*CuePoint, len(markers))
+	var labels, notes []*Label
+	var labeledTexts []*LabeledText
+
+	for i, m := range markers {
+		id := m.ID
+
+		if id == 0 {
+			for used[nextID] {
+				nextID++
+			}
+
+			id = nextID
+			used[id] = true
+		}
+
+		points[i] = &CuePoint{ID: id, Position: uint32(m.Position), DataChunkID: DATAID, SampleOffset: uint32(m.Position)}
+
+		if m.Name != "" {
+			labels = append(labels, &Label{CuePointID: id, Text: m.Name})
+		}
+
+		if m.Note != "" {
+			notes = append(notes, &Label{CuePointID: id, Text: m.Note})
+		}
+
+		if m.IsRegion() {
+			labeledTexts = append(labeledTexts, &LabeledText{CuePointID: id, SampleLength: uint32(m.Length), Purpose: regionPurpose})
+		}
+	}
+
+	return EncodeCueChunk(points), EncodeAdtlChunk(labels, notes, labeledTexts)
+}
+
+// ReadMarkers returns the markers of provided container, nil if the container has no chunk 'cue '.
+func ReadMarkers(reader io.ReaderAt, container *Container) ([]*Marker, error) {
+	cue, err := readCue(reader, container)
+
+	if err != nil || cue == nil {
+		return nil, err
+	}
+
+	adtl, err := readAdtl(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Markers(cue, adtl), nil
+}
+
+// WriteMarkers copies provided container to ws with chunk 'cue ' and chunk 'LIST' of type 'adtl'
+// replaced by provided markers.
+func WriteMarkers(ws io.WriteSeeker, reader io.ReaderAt, container *Container, markers []*Marker) error {
+	cue, adtl := EncodeMarkers(markers)
+
+	return ReplaceChunks(ws, reader, container, cue.Bytes(), adtl.Bytes())
+}
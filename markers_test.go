@@ -0,0 +1,88 @@
+package chunk
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMarkers(t *testing.T) {
+	markers := []*Marker{{Position: 100, Name: "Marker", Note: "Note"}, {ID: 1, Position: 50, Length: 200, Name: "Region"}}
+	cue, adtl := EncodeMarkers(markers)
+
+	assertEqual(t, cue.Points()[0].ID, uint32(2), "ID assigned")
+	assertEqual(t, cue.Points()[1].ID, uint32(1), "ID")
+
+	decoded := Markers(cue, adtl)
+
+	assertEqual(t, len(decoded), 2, "Markers length")
+	assertEqual(t, decoded[0].Position, uint64(50), "Position")
+	assertEqual(t, decoded[0].IsRegion(), true, "IsRegion")
+	assertEqual(t, decoded[0].Name, "Region", "Name")
+	assertEqual(t, decoded[1].IsRegion(), false, "IsRegion")
+	assertEqual(t, decoded[1].Name, "Marker", "Name")
+	assertEqual(t, decoded[1].Note, "Note", "Note")
+	assertEqual(t, len(Markers(nil, adtl)), 0, "Markers without cue")
+}
+
+func TestWriteMarkers(t *testing.T) {
+	dir := t.TempDir()
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), createTestSine(0.5, 1000, 44100, 1000, 1))
+	riff = append(riff, EncodeInfoChunk(map[string]string{InfoName: "Title"}).Bytes()...)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	markers, err := ReadMarkers(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(markers), 0, "Markers length")
+
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	err = WriteMarkers(out, reader, container, []*Marker{{Position: 100, Name: "A"}, {Position: 500, Length: 400, Name: "B"}})
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	markers, err = ReadMarkers(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(markers), 2, "Markers length")
+	assertEqual(t, markers[1].Length, uint64(400), "Length")
+	assertEqual(t, len(container.FindHeaders(LISTID)), 2, "LIST headers")
+
+	trimmed, _ := os.Create(filepath.Join(dir, "trimmed.wav"))
+	defer trimmed.Close()
+	err = Trim(trimmed, out, container, 200, 500)
+
+	assertNil(t, err, "err")
+
+	trimmed.Seek(0, 0)
+	container, _ = ReadRiff(trimmed.Name(), trimmed)
+	markers, _ = ReadMarkers(trimmed, container)
+
+	assertEqual(t, len(markers), 1, "Markers length after trim")
+	assertEqual(t, markers[0].Name, "B", "Name")
+	assertEqual(t, markers[0].Position, uint64(300), "Position")
+	assertEqual(t, markers[0].Length, uint64(200), "Length")
+
+	info, _ := findList(trimmed, container, INFOID)
+	data, _ := ReadChunk(trimmed, info)
+	decoded, _ := DecodeInfoChunk(data)
+
+	assertEqual(t, decoded.Name(), "Title", "Name")
+
+	resampled, _ := os.Create(filepath.Join(dir, "resampled.wav"))
+	defer resampled.Close()
+	err = Resample(resampled, trimmed, container, 88200)
+
+	assertNil(t, err, "err")
+
+	resampled.Seek(0, 0)
+	container, _ = ReadRiff(resampled.Name(), resampled)
+	markers, _ = ReadMarkers(resampled, container)
+
+	assertEqual(t, markers[0].Position, uint64(600), "Position after resample")
+	assertEqual(t, markers[0].Length, uint64(400), "Length after resample")
+}
@@ -0,0 +1,173 @@
+package chunk
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// MD5 is chunk 'MD5 ' containing the MD5 checksum of the sound data as written by BWF MetaEdit.
+type MD5 struct {
+	*Header
+	digest [md5.Size]byte
+}
+
+// Digest is the MD5 checksum of the sound data.
+func (m *MD5) Digest() [md5.Size]byte {
+	return m.digest
+}
+
+// String returns string represensation of chunk.
+func (m *MD5) String() string {
+	return fmt.Sprintf("Digest: %x", m.digest)
+}
+
+// Bytes converts MD5 to byte array. A new Header with id 'MD5 ' is created.
+//
+// Header size is set to real data size. An amount of 24 bytes is returned.
+// chunk header - 8 bytes
+// digest - 16 bytes
+func (m *MD5) Bytes() []byte {
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+
+	if m.Header != nil && m.Header.byteOrder != nil {
+		byteOrder = m.Header.byteOrder
+	}
+
+	data := make([]byte, md5.Size)
+	copy(data, m.digest[:])
+	header := EncodeChunkHeader(CreateFourCC(MD5ID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeMD5Chunk returns encoded chunk 'MD5 ' by provided digest.
+func EncodeMD5Chunk(digest [md5.Size]byte) *MD5 {
+	return encodeMD5Chunk(digest, binary.LittleEndian)
+}
+
+// encodeMD5Chunk returns encoded chunk 'MD5 ' by provided digest with a header of provided byte order.
+func encodeMD5Chunk(digest [md5.Size]byte, byteOrder binary.ByteOrder) *MD5 {
+	header := EncodeChunkHeader(CreateFourCC(MD5ID), md5.Size, byteOrder)
+
+	return &MD5{Header: header, digest: digest}
+}
+
+// DecodeMD5Chunk decodes provided byte array to MD5.
+// The header is decoded big endian, as written to AIFF, if the size matches the digest size only in this byte order.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// digest - 16 bytes
+func DecodeMD5Chunk(data []byte) (*MD5, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	m := &MD5{}
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+
+	if binary.LittleEndian.Uint32(data[IDSizeBytes:HeaderSizeBytes]) != md5.Size && binary.BigEndian.Uint32(data[IDSizeBytes:HeaderSizeBytes]) == md5.Size {
+		byteOrder = binary.BigEndian
+	}
+
+	m.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	err := binary.Read(buf, byteOrder, &m.digest)
+
+	return m, err
+}
+
+// EssenceMD5 returns the MD5 checksum of the sound data of provided container: the payload of chunk 'data'
+// or the sample frames of chunk 'SSND', without offset, block size and alignment bytes.
+func EssenceMD5(reader io.ReaderAt, container *Container) ([md5.Size]byte, error) {
+	var digest [md5.Size]byte
+	h := md5.New()
+	err := hashEssence(reader, container, h)
+	copy(digest[:], h.Sum(nil))
+
+	return digest, err
+}
+
+// EssenceSHA256 returns the SHA-256 checksum of the sound data of provided container (see EssenceMD5).
+func EssenceSHA256(reader io.ReaderAt, container *Container) ([sha256.Size]byte, error) {
+	var digest [sha256.Size]byte
+	h := sha256.New()
+	err := hashEssence(reader, container, h)
+	copy(digest[:], h.Sum(nil))
+
+	return digest, err
+}
+
+// WriteEssenceMD5 copies provided container to ws with chunk 'MD5 ' set to the MD5 checksum of the sound data.
+func WriteEssenceMD5(ws io.WriteSeeker, reader io.ReaderAt, container *Container) error {
+	digest, err := EssenceMD5(reader, container)
+
+	if err != nil {
+		return err
+	}
+
+	return ReplaceChunks(ws, reader, container, encodeMD5Chunk(digest, container.ByteOrder).Bytes())
+}
+
+// VerifyEssence recomputes the MD5 checksum of the sound data of provided container and compares it to
+// the checksum stored in chunk 'MD5 '. An error is returned if the container has no chunk 'MD5 '.
+func VerifyEssence(reader io.ReaderAt, container *Container) (bool, error) {
+	header, err := findHeader(container, MD5ID)
+
+	if err != nil {
+		return false, err
+	}
+
+	data, err := ReadChunk(reader, header)
+
+	if err != nil {
+		return false, err
+	}
+
+	m, err := DecodeMD5Chunk(data)
+
+	if err != nil {
+		return false, err
+	}
+
+	digest, err := EssenceMD5(reader, container)
+
+	if err != nil {
+		return false, err
+	}
+
+	return digest == m.Digest(), nil
+}
+
+// hashEssence writes the payload of chunk 'data' or the sample frames of chunk 'SSND' of provided container to h.
+func hashEssence(reader io.ReaderAt, container *Container, h hash.Hash) error {
+	if isAiff(container) {
+		ssnd, err := ReadSSND(reader, container)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(h, io.NewSectionReader(reader, int64(ssnd.DataPos()), int64(ssnd.DataSize())))
+
+		return err
+	}
+
+	header, err := findHeader(container, DATAID)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(h, io.NewSectionReader(reader, int64(header.StartPos()+HeaderSizeBytes), int64(header.Size())))
+
+	return err
+}
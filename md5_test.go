@@ -0,0 +1,140 @@
+package chunk
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMD5Chunk(t *testing.T) {
+	digest := md5.Sum([]byte("test"))
+	chunk := EncodeMD5Chunk(digest)
+
+	assertEqual(t, chunk.ID(), MD5ID, "ID")
+	assertEqual(t, chunk.Size(), uint32(16), "Size")
+
+	decoded, err := DecodeMD5Chunk(chunk.Bytes())
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Digest(), digest, "Digest")
+
+	_, err = DecodeMD5Chunk(chunk.Bytes()[:HeaderSizeBytes+4])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestVerifyEssence(t *testing.T) {
+	dir := t.TempDir()
+	data := createTestSine(0.5, 1000, 44100, 100, 1)
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+
+	_, err := VerifyEssence(reader, container)
+
+	assertNotNil(t, err, "err without MD5 chunk")
+
+	digest, err := EssenceMD5(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, digest, md5.Sum(data), "EssenceMD5")
+
+	sha, _ := EssenceSHA256(reader, container)
+
+	assertEqual(t, sha, sha256.Sum256(data), "EssenceSHA256")
+
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	err = WriteEssenceMD5(out, reader, container)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	ok, err := VerifyEssence(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, ok, true, "VerifyEssence")
+
+	data[10]++
+	header, _ := findHeader(container, DATAID)
+	out.WriteAt(data, int64(header.StartPos()+HeaderSizeBytes))
+	ok, _ = VerifyEssence(out, container)
+
+	assertEqual(t, ok, false, "VerifyEssence after change")
+}
+
+func TestVerifyEssenceAiff(t *testing.T) {
+	dir := t.TempDir()
+	data := createTestSine(0.5, 1000, 44100, 100, 1)
+	comm := EncodeCOMMChunk(18, 1, 100, 16, 44100, FourCC{}, "")
+	aligned := bytes.NewReader(createTestAiff(comm, 8, data))
+	container, _ := ReadAiff("test", aligned)
+	digest, err := EssenceMD5(aligned, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, digest, md5.Sum(data), "EssenceMD5 without offset and alignment")
+
+	reader := bytes.NewReader(createTestAiff(comm, 0, data))
+	container, _ = ReadAiff("test", reader)
+	out, _ := os.Create(filepath.Join(dir, "out.aiff"))
+	defer out.Close()
+	err = WriteEssenceMD5(out, reader, container)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadAiff(out.Name(), out)
+	header, _ := findHeader(container, MD5ID)
+
+	assertEqual(t, header.Size(), uint32(md5.Size), "MD5 chunk size")
+
+	ok, err := VerifyEssence(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, ok, true, "VerifyEssence")
+
+	chunk, _ := ReadChunk(out, header)
+	m, _ := DecodeMD5Chunk(chunk)
+
+	assertEqual(t, bytes.Equal(m.Bytes(), chunk), true, "Bytes in big endian")
+}
+
+func TestTrimResampleEssenceMD5(t *testing.T) {
+	dir := t.TempDir()
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), createTestSine(0.5, 1000, 44100, 1000, 1))
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	in, _ := os.Create(filepath.Join(dir, "in.wav"))
+	defer in.Close()
+	WriteEssenceMD5(in, reader, container)
+	in.Seek(0, 0)
+	container, _ = ReadRiff(in.Name(), in)
+
+	trimmed, _ := os.Create(filepath.Join(dir, "trimmed.wav"))
+	defer trimmed.Close()
+	err := Trim(trimmed, in, container, 100, 500)
+
+	assertNil(t, err, "err")
+
+	trimmed.Seek(0, 0)
+	trimmedContainer, _ := ReadRiff(trimmed.Name(), trimmed)
+	ok, err := VerifyEssence(trimmed, trimmedContainer)
+
+	assertNil(t, err, "err")
+	assertEqual(t, ok, true, "VerifyEssence after Trim")
+
+	resampled, _ := os.Create(filepath.Join(dir, "resampled.wav"))
+	defer resampled.Close()
+	err = Resample(resampled, in, container, 48000)
+
+	assertNil(t, err, "err")
+
+	resampled.Seek(0, 0)
+	resampledContainer, _ := ReadRiff(resampled.Name(), resampled)
+
+	assertEqual(t, len(resampledContainer.FindHeaders(MD5ID)), 0, "MD5 chunks after Resample")
+}
@@ -0,0 +1,167 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// PeakVersion1 is the version of chunk 'PEAK'.
+const PeakVersion1 uint32 = 1
+
+// PeakValue is the peak of a channel.
+type PeakValue struct {
+	// Value is the absolute peak value relative to full scale (1.0).
+	Value float32
+	// Position is the frame of the peak value.
+	Position uint32
+}
+
+// Peak is chunk 'PEAK' containing the peak of each channel, written by audio editors to avoid rescanning the
+// sound data. It is little endian in WAVE and big endian in AIFF / AIFF-C containers.
+type Peak struct {
+	*Header
+	version   uint32
+	timestamp uint32
+	peaks     []PeakValue
+}
+
+// Version is the version of the chunk, usually PeakVersion1.
+func (p *Peak) Version() uint32 {
+	return p.version
+}
+
+// Timestamp is the time of creation of the peak data.
+func (p *Peak) Timestamp() time.Time {
+	return time.Unix(int64(p.timestamp), 0).UTC()
+}
+
+// SetTimestamp sets the time of creation of the peak data, truncated to seconds.
+func (p *Peak) SetTimestamp(value time.Time) {
+	p.timestamp = uint32(value.Unix())
+}
+
+// Peaks returns the peak of each channel.
+func (p *Peak) Peaks() []PeakValue {
+	return p.peaks
+}
+
+// SetPeaks sets the peak of each channel.
+func (p *Peak) SetPeaks(peaks []PeakValue) {
+	p.peaks = peaks
+}
+
+// String returns string represensation of chunk.
+func (p *Peak) String() string {
+	lines := make([]string, len(p.peaks))
+
+	for i, v := range p.peaks {
+		lines[i] = fmt.Sprintf("Channel %d: %f (frame %d)", i+1, v.Value, v.Position)
+	}
+
+	return fmt.Sprintf("Version: %d\nTimestamp: %s\n%s", p.version, p.Timestamp(), strings.Join(lines, "\n"))
+}
+
+// Bytes converts Peak to byte array in the byte order of the chunk. A new Header with id 'PEAK' is created.
+//
+// Header size is set to real data size. A minimum amount of 16 bytes is returned.
+// chunk header - 8 bytes
+// version - 4 bytes
+// timestamp - 4 bytes
+// peaks - 8 bytes each: value (4 bytes float), position (4 bytes)
+func (p *Peak) Bytes() []byte {
+	byteOrder := p.byteOrder
+	data := make([]byte, 8+8*len(p.peaks))
+	byteOrder.PutUint32(data[0:4], p.version)
+	byteOrder.PutUint32(data[4:8], p.timestamp)
+
+	for i, v := range p.peaks {
+		pos := 8 + 8*i
+		byteOrder.PutUint32(data[pos:pos+4], math.Float32bits(v.Value))
+		byteOrder.PutUint32(data[pos+4:pos+8], v.Position)
+	}
+
+	header := EncodeChunkHeader(CreateFourCC(PEAKID), uint32(len(data)), byteOrder)
+
+	return append(header.Bytes(), data...)
+}
+
+// EncodePeakChunk returns encoded chunk 'PEAK' of version PeakVersion1 in provided byte order,
+// little endian for WAVE and big endian for AIFF / AIFF-C.
+func EncodePeakChunk(byteOrder binary.ByteOrder, timestamp time.Time, peaks []PeakValue) *Peak {
+	header := EncodeChunkHeader(CreateFourCC(PEAKID), uint32(8+8*len(peaks)), byteOrder)
+	p := &Peak{Header: header, version: PeakVersion1, peaks: peaks}
+	p.SetTimestamp(timestamp)
+
+	return p
+}
+
+// DecodePeakChunk decodes provided byte array to Peak. The byte order is detected by the version.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// version - 4 bytes
+// timestamp - 4 bytes (min. requirement for successful decoding)
+// peaks - 8 bytes each
+func DecodePeakChunk(data []byte) (*Peak, error) {
+	if len(data) < int(HeaderSizeBytes)+8 {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", int(HeaderSizeBytes)+8)
+		return nil, errors.New(msg)
+	}
+
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+
+	if binary.BigEndian.Uint32(data[HeaderSizeBytes:HeaderSizeBytes+4]) == PeakVersion1 {
+		byteOrder = binary.BigEndian
+	}
+
+	p := &Peak{Header: decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)}
+	p.version = byteOrder.Uint32(data[HeaderSizeBytes : HeaderSizeBytes+4])
+	p.timestamp = byteOrder.Uint32(data[HeaderSizeBytes+4 : HeaderSizeBytes+8])
+	end := int(HeaderSizeBytes + p.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	for pos := int(HeaderSizeBytes) + 8; pos+8 <= end; pos += 8 {
+		value := math.Float32frombits(byteOrder.Uint32(data[pos : pos+4]))
+		p.peaks = append(p.peaks, PeakValue{Value: value, Position: byteOrder.Uint32(data[pos+4 : pos+8])})
+	}
+
+	return p, nil
+}
+
+// GeneratePeak scans the sound data of provided container and returns chunk 'PEAK' with the peak of each channel,
+// timestamped with the current time in the byte order of the container.
+func GeneratePeak(reader io.ReaderAt, container *Container) (*Peak, error) {
+	levels, err := AnalyzeLevels(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	peaks := make([]PeakValue, len(levels.Channels))
+
+	for i, c := range levels.Channels {
+		peaks[i] = PeakValue{Value: float32(c.Peak), Position: uint32(c.PeakPosition)}
+	}
+
+	return EncodePeakChunk(container.ByteOrder, time.Now(), peaks), nil
+}
+
+// WritePeak copies provided container to ws with chunk 'PEAK' regenerated from the sound data,
+// replacing stale peak data after edits.
+func WritePeak(ws io.WriteSeeker, reader io.ReaderAt, container *Container) error {
+	peak, err := GeneratePeak(reader, container)
+
+	if err != nil {
+		return err
+	}
+
+	return ReplaceChunks(ws, reader, container, peak.Bytes())
+}
@@ -0,0 +1,103 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPeak(t *testing.T) {
+	timestamp := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	peaks := []PeakValue{{Value: 0.5, Position: 10}, {Value: 1, Position: 20}}
+
+	for _, byteOrder := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		chunk := EncodePeakChunk(byteOrder, timestamp, peaks)
+
+		assertEqual(t, chunk.ID(), PEAKID, "ID")
+		assertEqual(t, chunk.Size(), uint32(24), "Size")
+
+		data := chunk.Bytes()
+
+		assertEqual(t, len(data), 32, "Bytes length")
+		assertEqual(t, byteOrder.Uint32(data[4:8]), uint32(24), "Size in byte order")
+
+		decoded, err := DecodePeakChunk(data)
+
+		assertNil(t, err, "err")
+		assertEqual(t, decoded.Version(), PeakVersion1, "Version")
+		assertEqual(t, decoded.Timestamp(), timestamp, "Timestamp")
+		assertEqual(t, len(decoded.Peaks()), 2, "peaks length")
+		assertEqual(t, decoded.Peaks()[1], peaks[1], "peak")
+
+		_, err = DecodePeakChunk(data[:15])
+
+		assertNotNil(t, err, "err with short data")
+	}
+}
+
+func TestWritePeak(t *testing.T) {
+	data := createTestSine(0.5, 1000, 44100, 100, 1)
+	stale := EncodePeakChunk(binary.LittleEndian, time.Unix(0, 0), []PeakValue{{Value: 1, Position: 1}})
+	riff := append(createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), data), stale.Bytes()...)
+	binary.LittleEndian.PutUint32(riff[4:8], uint32(len(riff)-8))
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+
+	out, _ := os.Create(filepath.Join(t.TempDir(), "out.wav"))
+	defer out.Close()
+	err := WritePeak(out, reader, container)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	headers := container.FindHeaders(PEAKID)
+
+	assertEqual(t, len(headers), 1, "headers length")
+
+	chunkData, _ := ReadChunk(out, headers[0])
+	peak, err := DecodePeakChunk(chunkData)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(peak.Peaks()), 1, "peaks length")
+	assertEqual(t, peak.Peaks()[0].Value > 0.49 && peak.Peaks()[0].Value < 0.51, true, "Value")
+	assertEqual(t, peak.Timestamp().After(time.Unix(0, 0)), true, "Timestamp")
+}
+
+func TestTrimResampleDropPeaks(t *testing.T) {
+	dir := t.TempDir()
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), createTestSine(0.5, 1000, 44100, 1000, 1))
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	peak, _ := GeneratePeak(reader, container)
+	w, _ := GenerateWaveform(reader, container, 256)
+	levl, _ := w.Levl(LevlFormatUint16, time.Now())
+	in, _ := os.Create(filepath.Join(dir, "in.wav"))
+	defer in.Close()
+	ReplaceChunks(in, reader, container, peak.Bytes(), levl.Bytes())
+	in.Seek(0, 0)
+	container, _ = ReadRiff(in.Name(), in)
+
+	assertEqual(t, len(container.FindHeaders(PEAKID)), 1, "PEAK chunks")
+	assertEqual(t, len(container.FindHeaders(LEVLID)), 1, "levl chunks")
+
+	for name, write := range map[string]func(ws *os.File) error{
+		"Trim":     func(ws *os.File) error { return Trim(ws, in, container, 100, 500) },
+		"Resample": func(ws *os.File) error { return Resample(ws, in, container, 48000) },
+	} {
+		out, _ := os.Create(filepath.Join(dir, name+".wav"))
+		defer out.Close()
+		err := write(out)
+
+		assertNil(t, err, "err")
+
+		out.Seek(0, 0)
+		outContainer, _ := ReadRiff(out.Name(), out)
+
+		assertEqual(t, len(outContainer.FindHeaders(PEAKID)), 0, "PEAK chunks after "+name)
+		assertEqual(t, len(outContainer.FindHeaders(LEVLID)), 0, "levl chunks after "+name)
+	}
+}
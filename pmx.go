@@ -0,0 +1,144 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ApplXMPSignature is the application signature of chunk 'APPL' containing an XMP packet.
+const ApplXMPSignature = "XMP "
+
+// PMX is chunk '_PMX' containing an XMP packet as written by Adobe applications to WAVE files.
+type PMX struct {
+	*Header
+	data []byte
+}
+
+// Data returns the XMP packet as stored in the chunk.
+func (p *PMX) Data() []byte {
+	return p.data
+}
+
+// XMP decodes the XMP packet.
+func (p *PMX) XMP() (*XMP, error) {
+	return DecodeXMP(p.data)
+}
+
+// SetXMP sets the XMP packet to encoded provided XMP.
+func (p *PMX) SetXMP(xmp *XMP) {
+	p.data = xmp.Bytes()
+}
+
+// String returns string represensation of chunk.
+func (p *PMX) String() string {
+	return string(p.data)
+}
+
+// Bytes converts PMX to byte array. A new Header with id '_PMX' is created.
+//
+// Header size is set to real data size. A minimum amount of 8 bytes is returned.
+// chunk header - 8 bytes
+// XMP packet - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (p *PMX) Bytes() []byte {
+	header := EncodeChunkHeader(CreateFourCC(PMXID), uint32(len(p.data)), binary.LittleEndian)
+	bytes := append(header.Bytes(), p.data...)
+
+	return pad(bytes)
+}
+
+// EncodePMXChunk returns encoded chunk '_PMX' containing provided XMP.
+func EncodePMXChunk(xmp *XMP) *PMX {
+	data := xmp.Bytes()
+	header := EncodeChunkHeader(CreateFourCC(PMXID), uint32(len(data)), binary.LittleEndian)
+
+	return &PMX{Header: header, data: data}
+}
+
+// DecodePMXChunk decodes provided byte array to PMX.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// XMP packet - not restricted amount of bytes
+func DecodePMXChunk(data []byte) (*PMX, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	header := decodeChunkHeader(data[:HeaderSizeBytes], 0, binary.LittleEndian)
+	end := int(HeaderSizeBytes + header.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	return &PMX{Header: header, data: append([]byte{}, data[HeaderSizeBytes:end]...)}, nil
+}
+
+// ReadXMP reads the XMP packet of chunk '_PMX' of a RIFF container or of chunk 'APPL' with signature 'XMP '
+// of an AIFF container. Nil is returned if not present.
+func ReadXMP(reader io.ReaderAt, container *Container) (*XMP, error) {
+	if !isAiff(container) {
+		headers := container.FindHeaders(PMXID)
+
+		if len(headers) == 0 {
+			return nil, nil
+		}
+
+		data, err := ReadChunk(reader, headers[0])
+
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := DecodePMXChunk(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return p.XMP()
+	}
+
+	for _, header := range container.FindHeaders(APPLID) {
+		signature, err := readListType(reader, header)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if signature != ApplXMPSignature {
+			continue
+		}
+
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return nil, err
+		}
+
+		a, err := DecodeApplChunk(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return DecodeXMP(a.Data())
+	}
+
+	return nil, nil
+}
+
+// WriteXMP copies provided container to ws with the XMP packet set to provided XMP, in chunk '_PMX' for
+// RIFF and in chunk 'APPL' with signature 'XMP ' for AIFF containers. Other chunks 'APPL' are preserved.
+func WriteXMP(ws io.WriteSeeker, reader io.ReaderAt, container *Container, xmp *XMP) error {
+	if isAiff(container) {
+		return ReplaceChunks(ws, reader, container, EncodeApplChunk(ApplXMPSignature, xmp.Bytes()).Bytes())
+	}
+
+	return ReplaceChunks(ws, reader, container, EncodePMXChunk(xmp).Bytes())
+}
@@ -0,0 +1,92 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPMX(t *testing.T) {
+	x := NewXMP()
+	x.SetTitle("Title")
+	chunk := EncodePMXChunk(x)
+
+	assertEqual(t, chunk.ID(), PMXID, "ID")
+
+	data := chunk.Bytes()
+
+	assertEqual(t, binary.LittleEndian.Uint32(data[4:8]), chunk.Size(), "Size")
+
+	decoded, err := DecodePMXChunk(data)
+
+	assertNil(t, err, "err")
+
+	xmp, err := decoded.XMP()
+
+	assertNil(t, err, "err")
+	assertEqual(t, xmp.Title(), "Title", "Title")
+
+	_, err = DecodePMXChunk(data[:HeaderSizeBytes-1])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestWriteXMP(t *testing.T) {
+	dir := t.TempDir()
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), make([]byte, 4))
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	x, _ := ReadXMP(reader, container)
+
+	assertEqual(t, x == nil, true, "XMP not present")
+
+	x = NewXMP()
+	x.SetScene("1")
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	err := WriteXMP(out, reader, container, x)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, io.SeekStart)
+	container, _ = ReadRiff(out.Name(), out)
+	x, err = ReadXMP(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, x.Scene(), "1", "Scene")
+}
+
+func TestWriteXMPAiff(t *testing.T) {
+	dir := t.TempDir()
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 2, 16, 44100, FourCC{}, ""), 0, make([]byte, 4))
+	aiff = append(aiff, EncodeApplChunk("stoc", append(encodePascalString("Editor"), 1)).Bytes()...)
+	binary.BigEndian.PutUint32(aiff[4:8], uint32(len(aiff)-8))
+	reader := bytes.NewReader(aiff)
+	container, _ := ReadAiff("test", reader)
+
+	x := NewXMP()
+	x.SetScene("2")
+	out, _ := os.Create(filepath.Join(dir, "out.aiff"))
+	defer out.Close()
+	err := WriteXMP(out, reader, container, x)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, io.SeekStart)
+	container, _ = ReadAiff(out.Name(), out)
+	x, err = ReadXMP(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, x.Scene(), "2", "Scene")
+	assertEqual(t, len(container.FindHeaders(APPLID)), 2, "other APPL preserved")
+
+	data, _ := ReadChunk(out, container.FindHeaders(APPLID)[1])
+	appl, _ := DecodeApplChunk(data)
+	value, err := appl.Decode()
+
+	assertNil(t, err, "err")
+	assertEqual(t, value.(*XMP).Scene(), "2", "Scene by APPL decoder")
+}
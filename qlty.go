@@ -0,0 +1,313 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// QltyStart is the quality event type of the start of modulation of a transfer.
+	QltyStart = "S"
+	// QltyEnd is the quality event type of the end of modulation of a transfer.
+	QltyEnd = "E"
+	// QltyClick is the quality event type of a click.
+	QltyClick = "C"
+	// QltyDropout is the quality event type of a dropout.
+	QltyDropout = "D"
+	// QltyComment is the quality event type of an operator comment.
+	QltyComment = "O"
+	// qltyFieldsSize is the byte size of the file security codes of chunk 'qlty'.
+	qltyFieldsSize = 8
+)
+
+// QltyBasicData is the basic data of a transfer in chunk 'qlty'.
+type QltyBasicData struct {
+	// ArchiveNumber identifies the source carrier in the archive.
+	ArchiveNumber string
+	// Title is the title of the recording.
+	Title string
+	// Duration is the duration of the recording: hh:mm:ss.
+	Duration string
+	// Date is the date of the transfer: yyyy-mm-dd.
+	Date string
+	// Operator is the name of the operator of the transfer.
+	Operator string
+	// CopyingStation identifies the equipment of the transfer.
+	CopyingStation string
+}
+
+// QltyEvent is an event of the quality event log of chunk 'qlty'.
+type QltyEvent struct {
+	// Type is the event type, e.g. QltyClick.
+	Type string
+	// Position is the time of the event relative to the start of the sound data.
+	Position time.Duration
+	// Priority is the priority of the event from 1 (low) to 5 (high), 0 if not set.
+	Priority int
+	// Value is the measured value of the event, e.g. the level of a click.
+	Value string
+	// Comment is the text of the event, e.g. the operator comment.
+	Comment string
+}
+
+// String returns string represensation of event.
+func (e *QltyEvent) String() string {
+	return fmt.Sprintf("%s %s Priority: %d Value: %s Comment: %s", e.Type, formatQltyPosition(e.Position), e.Priority, e.Value, e.Comment)
+}
+
+// Qlty is Broadcast Wave Format (BWF) quality chunk 'qlty' as specified in EBU Tech 3285 Supplement 2.
+// It contains file security codes followed by the quality report as ASCII text of CR LF terminated lines:
+//
+// AN=, TT=, DU=, DD=, OP=, CS= - basic data
+// E=type TAB hh:mm:ss.mmm TAB priority TAB value TAB comment - quality events
+//
+// Unknown lines are preserved.
+type Qlty struct {
+	*Header
+	fileSecurityReport uint32
+	fileSecurityWave   uint32
+	basicData          QltyBasicData
+	events             []*QltyEvent
+	lines              []string
+}
+
+// FileSecurityReport is the file security code of the quality report.
+func (q *Qlty) FileSecurityReport() uint32 {
+	return q.fileSecurityReport
+}
+
+// SetFileSecurityReport sets the file security code of the quality report.
+func (q *Qlty) SetFileSecurityReport(value uint32) {
+	q.fileSecurityReport = value
+}
+
+// FileSecurityWave is the file security code of the sound data.
+func (q *Qlty) FileSecurityWave() uint32 {
+	return q.fileSecurityWave
+}
+
+// SetFileSecurityWave sets the file security code of the sound data.
+func (q *Qlty) SetFileSecurityWave(value uint32) {
+	q.fileSecurityWave = value
+}
+
+// BasicData returns the basic data of the transfer.
+func (q *Qlty) BasicData() QltyBasicData {
+	return q.basicData
+}
+
+// SetBasicData sets the basic data of the transfer.
+func (q *Qlty) SetBasicData(value QltyBasicData) {
+	q.basicData = value
+}
+
+// Events returns the quality event log.
+func (q *Qlty) Events() []*QltyEvent {
+	return q.events
+}
+
+// SetEvents sets the quality event log.
+func (q *Qlty) SetEvents(events []*QltyEvent) {
+	q.events = events
+}
+
+// AddEvent appends an event to the quality event log.
+func (q *Qlty) AddEvent(event *QltyEvent) {
+	q.events = append(q.events, event)
+}
+
+// FindEvents returns the events of provided type.
+func (q *Qlty) FindEvents(eventType string) []*QltyEvent {
+	var events []*QltyEvent
+
+	for _, e := range q.events {
+		if e.Type == eventType {
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
+
+// String returns string represensation of chunk.
+func (q *Qlty) String() string {
+	return q.report()
+}
+
+// Bytes converts Qlty to byte array. A new Header with id 'qlty' is created.
+//
+// Header size is set to real data size. A minimum amount of 16 bytes is returned.
+// chunk header - 8 bytes
+// file security code of report - 4 bytes
+// file security code of sound data - 4 bytes
+// quality report - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (q *Qlty) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	data := make([]byte, qltyFieldsSize)
+	byteOrder.PutUint32(data[0:4], q.fileSecurityReport)
+	byteOrder.PutUint32(data[4:8], q.fileSecurityWave)
+	data = append(data, q.report()...)
+	header := EncodeChunkHeader(CreateFourCC(QLTYID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// report returns the quality report text.
+func (q *Qlty) report() string {
+	var sb strings.Builder
+	basic := []struct{ code, value string }{
+		{"AN", q.basicData.ArchiveNumber},
+		{"TT", q.basicData.Title},
+		{"DU", q.basicData.Duration},
+		{"DD", q.basicData.Date},
+		{"OP", q.basicData.Operator},
+		{"CS", q.basicData.CopyingStation},
+	}
+
+	for _, b := range basic {
+		if b.value != "" {
+			sb.WriteString(b.code + "=" + b.value + "\r\n")
+		}
+	}
+
+	for _, e := range q.events {
+		fields := []string{e.Type, formatQltyPosition(e.Position), strconv.Itoa(e.Priority), e.Value, e.Comment}
+		sb.WriteString("E=" + strings.Join(fields, "\t") + "\r\n")
+	}
+
+	for _, l := range q.lines {
+		sb.WriteString(l + "\r\n")
+	}
+
+	return sb.String()
+}
+
+// EncodeQltyChunk returns encoded chunk 'qlty' containing provided basic data and quality events.
+func EncodeQltyChunk(basicData QltyBasicData, events []*QltyEvent) *Qlty {
+	q := &Qlty{basicData: basicData, events: events}
+	q.Header = EncodeChunkHeader(CreateFourCC(QLTYID), uint32(qltyFieldsSize+len(q.report())), binary.LittleEndian)
+
+	return q
+}
+
+// DecodeQltyChunk decodes provided byte array to Qlty.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// file security code of report - 4 bytes
+// file security code of sound data - 4 bytes (min. requirement for successful decoding)
+// quality report - not restricted amount of bytes
+func DecodeQltyChunk(data []byte) (*Qlty, error) {
+	if len(data) < int(HeaderSizeBytes)+qltyFieldsSize {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", int(HeaderSizeBytes)+qltyFieldsSize)
+		return nil, errors.New(msg)
+	}
+
+	byteOrder := binary.LittleEndian
+	q := &Qlty{Header: decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)}
+	q.fileSecurityReport = byteOrder.Uint32(data[HeaderSizeBytes : HeaderSizeBytes+4])
+	q.fileSecurityWave = byteOrder.Uint32(data[HeaderSizeBytes+4 : HeaderSizeBytes+8])
+	end := int(HeaderSizeBytes + q.Size())
+
+	if end > len(data) {
+		end = len(data)
+	}
+
+	report := nullTermToString(data[HeaderSizeBytes+qltyFieldsSize : end])
+
+	for _, line := range strings.Split(strings.ReplaceAll(report, "\r\n", "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+
+		err := q.decodeLine(line)
+
+		if err != nil {
+			return q, err
+		}
+	}
+
+	return q, nil
+}
+
+// decodeLine decodes a line of the quality report.
+func (q *Qlty) decodeLine(line string) error {
+	i := strings.Index(line, "=")
+
+	if i < 0 {
+		q.lines = append(q.lines, line)
+		return nil
+	}
+
+	value := line[i+1:]
+
+	switch line[:i] {
+	case "AN":
+		q.basicData.ArchiveNumber = value
+	case "TT":
+		q.basicData.Title = value
+	case "DU":
+		q.basicData.Duration = value
+	case "DD":
+		q.basicData.Date = value
+	case "OP":
+		q.basicData.Operator = value
+	case "CS":
+		q.basicData.CopyingStation = value
+	case "E":
+		fields := strings.SplitN(value, "\t", 5)
+
+		for len(fields) < 5 {
+			fields = append(fields, "")
+		}
+
+		position, err := parseQltyPosition(fields[1])
+
+		if err != nil {
+			return err
+		}
+
+		priority := 0
+
+		if fields[2] != "" {
+			priority, err = strconv.Atoi(fields[2])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		q.events = append(q.events, &QltyEvent{Type: fields[0], Position: position, Priority: priority, Value: fields[3], Comment: fields[4]})
+	default:
+		q.lines = append(q.lines, line)
+	}
+
+	return nil
+}
+
+// formatQltyPosition formats provided position as hh:mm:ss.mmm.
+func formatQltyPosition(position time.Duration) string {
+	ms := position.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// parseQltyPosition parses a position formatted as hh:mm:ss.mmm.
+func parseQltyPosition(value string) (time.Duration, error) {
+	var h, m, s, ms int64
+	_, err := fmt.Sscanf(value, "%d:%d:%d.%d", &h, &m, &s, &ms)
+
+	if err != nil {
+		msg := fmt.Sprintf("invalid quality event position '%s'", value)
+		return 0, errors.New(msg)
+	}
+
+	return time.Duration(((h*60+m)*60+s)*1000+ms) * time.Millisecond, nil
+}
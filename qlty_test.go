@@ -0,0 +1,46 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQlty(t *testing.T) {
+	basicData := QltyBasicData{ArchiveNumber: "A-123", Title: "Title", Date: "2021-03-04", Operator: "Operator"}
+	events := []*QltyEvent{
+		{Type: QltyStart, Position: 1500 * time.Millisecond},
+		{Type: QltyClick, Position: 62*time.Second + 250*time.Millisecond, Priority: 3, Value: "-12dB"},
+		{Type: QltyComment, Position: time.Hour, Comment: "tape splice"},
+	}
+	chunk := EncodeQltyChunk(basicData, events)
+	chunk.SetFileSecurityWave(0x12345678)
+
+	assertEqual(t, chunk.ID(), QLTYID, "ID")
+
+	data := chunk.Bytes()
+
+	assertEqual(t, binary.LittleEndian.Uint32(data[4:8]), chunk.Size(), "Size")
+	assertEqual(t, strings.Contains(string(data), "E=C\t00:01:02.250\t3\t-12dB\t\r\n"), true, "event line")
+
+	decoded, err := DecodeQltyChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.FileSecurityWave(), uint32(0x12345678), "FileSecurityWave")
+	assertEqual(t, decoded.BasicData(), basicData, "BasicData")
+	assertEqual(t, len(decoded.Events()), 3, "events length")
+	assertEqual(t, *decoded.Events()[1], *events[1], "event")
+	assertEqual(t, decoded.FindEvents(QltyComment)[0].Comment, "tape splice", "comment")
+	assertEqual(t, decoded.FindEvents(QltyComment)[0].Position, time.Hour, "comment position")
+
+	_, err = DecodeQltyChunk(data[:HeaderSizeBytes+4])
+
+	assertNotNil(t, err, "err with short data")
+
+	bad := EncodeQltyChunk(QltyBasicData{}, nil)
+	bad.lines = []string{"E=C\tinvalid"}
+	_, err = DecodeQltyChunk(bad.Bytes())
+
+	assertNotNil(t, err, "err with invalid position")
+}
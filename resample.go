@@ -0,0 +1,203 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/metawav/chunk/internal"
+)
+
+// Resample converts the sound data of provided container to sampleRate and writes the result to ws
+// as a container of the same type. A polyphase windowed-sinc filter is applied to each channel.
+//
+// Format chunk 'fmt ' or common chunk 'COMM' is updated to the new sample rate and sample based metadata
+// is rescaled: bext time reference, iXML timestamp, time reference and sync points, cue points, region lengths,
+// sample period and loops of the sampler chunk and AIFF markers.
+// Chunk 'MD5 ' is removed as the checksum of the resampled sound data is not known before it is written.
+// Peak chunks 'PEAK' and 'levl' are removed as their values and positions no longer match,
+// use WritePeak to regenerate 'PEAK'.
+// All other chunks are copied unchanged.
+func Resample(ws io.WriteSeeker, reader io.ReaderAt, container *Container, sampleRate int) error {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return err
+	}
+
+	err = fr.validateSamples()
+
+	if err != nil {
+		return err
+	}
+
+	from := fr.SampleRate()
+
+	if from <= 0 || sampleRate <= 0 {
+		return errors.New("sample rates must be greater than 0")
+	}
+
+	scale := func(value uint64) uint64 {
+		return uint64(math.Round(float64(value) * float64(sampleRate) / float64(from)))
+	}
+
+	numFrames := internal.NewResampler(from, sampleRate).OutputLength(fr.NumFrames())
+	soundID := DATAID
+
+	if isAiff(container) {
+		soundID = SSNDID
+	}
+
+	// fail before writing if the resampled sound data exceeds the max. chunk size
+	_, err = soundDataSize(soundID, numFrames, fr.BlockAlign())
+
+	if err != nil {
+		return err
+	}
+
+	w, err := newContainerWriter(ws, container)
+
+	if err != nil {
+		return err
+	}
+
+	rewriters := map[string]chunkRewriter{
+		FMTID: func(w *Writer, header *Header) error {
+			data, err := ReadChunk(reader, header)
+
+			if err != nil {
+				return err
+			}
+
+			binary.LittleEndian.PutUint32(data[HeaderSizeBytes+4:HeaderSizeBytes+8], uint32(sampleRate))
+			binary.LittleEndian.PutUint32(data[HeaderSizeBytes+8:HeaderSizeBytes+12], uint32(sampleRate*fr.BlockAlign()))
+
+			return w.WriteChunk(data)
+		},
+		COMMID: rewriteCOMM(reader, numFrames, sampleRate),
+		FACTID: rewriteFact(reader, numFrames),
+		BEXTID: rewriteBext(reader, func(b *Bext) {
+			b.SetTimeReference(scale(b.TimeReference()))
+		}),
+		IXMLID: rewriteIXML(reader, func(c *IXML) {
+			c.updateSamples(scale, scale, scale)
+
+			if c.Speed == nil {
+				return
+			}
+
+			if c.Speed.FileSampleRate != "" {
+				c.Speed.FileSampleRate = strconv.Itoa(sampleRate)
+			}
+
+			if c.Speed.TimestampSampleRate != "" {
+				c.Speed.TimestampSampleRate = strconv.Itoa(sampleRate)
+			}
+		}),
+		CUEID: rewriteCue(reader, func(p *CuePoint) bool {
+			p.Position = uint32(scale(uint64(p.Position)))
+			p.SampleOffset = uint32(scale(uint64(p.SampleOffset)))
+
+			return true
+		}),
+		LISTID: rewriteAdtl(reader, func(a *Adtl) {
+			for _, t := range a.LabeledTexts() {
+				t.SampleLength = uint32(scale(uint64(t.SampleLength)))
+			}
+		}),
+		SMPLID: rewriteSmpl(reader, func(s *Smpl) {
+			s.SetSampleRate(sampleRate)
+
+			for _, l := range s.Loops() {
+				l.Start = uint32(scale(uint64(l.Start)))
+				l.End = uint32(scale(uint64(l.End)))
+			}
+		}),
+		MARKID: rewriteMARK(reader, scale),
+		PEAKID: dropChunk,
+		LEVLID: dropChunk,
+		MD5ID:  dropChunk,
+		DATAID: func(w *Writer, header *Header) error {
+			return writeResampled(w, fr, DATAID, sampleRate, numFrames)
+		},
+		SSNDID: func(w *Writer, header *Header) error {
+			return writeResampled(w, fr, SSNDID, sampleRate, numFrames)
+		},
+	}
+
+	return rewrite(w, reader, container, rewriters)
+}
+
+// writeResampled writes the resampled sound data of provided FrameReader as chunk with provided id.
+func writeResampled(w *Writer, fr *FrameReader, id string, sampleRate int, numFrames uint64) error {
+	size, err := soundDataSize(id, numFrames, fr.BlockAlign())
+
+	if err != nil {
+		return err
+	}
+
+	cw, err := createSoundChunk(w, id, size)
+
+	if err != nil {
+		return err
+	}
+
+	channels := fr.Channels()
+	resamplers := make([]*internal.Resampler, channels)
+
+	for c := range resamplers {
+		resamplers[c] = internal.NewResampler(fr.SampleRate(), sampleRate)
+	}
+
+	const blockFrames = 4096
+	samples := make([]float64, blockFrames*channels)
+	channelSamples := make([]float64, blockFrames)
+	out := make([][]float64, channels)
+
+	write := func() error {
+		frames := len(out[0])
+		interleaved := make([]float64, frames*channels)
+
+		for c := range out {
+			for f, value := range out[c] {
+				interleaved[f*channels+c] = value
+			}
+		}
+
+		data := make([]byte, frames*fr.BlockAlign())
+		fr.encodeSamples(interleaved, data)
+		_, err := cw.Write(data)
+
+		return err
+	}
+
+	for start := uint64(0); start < fr.NumFrames(); start += blockFrames {
+		n, err := fr.ReadSamples(start, blockFrames, samples)
+
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		for c, r := range resamplers {
+			for f := 0; f < n; f++ {
+				channelSamples[f] = samples[f*channels+c]
+			}
+
+			out[c] = r.Process(channelSamples[:n], out[c][:0])
+		}
+
+		err = write()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for c, r := range resamplers {
+		out[c] = r.Flush(out[c][:0])
+	}
+
+	return write()
+}
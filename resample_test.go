@@ -0,0 +1,183 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResample(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "in.wav")
+	bext := &Bext{}
+	bext.SetTimeReference(44100 * 3600)
+	ixml := &IXML{
+		Speed:         &IXMLSpeed{FileSampleRate: "44100", TimestampSampleRate: "44100", TimestampSamplesSinceMidnightHi: "0", TimestampSamplesSinceMidnightLo: "158760000"},
+		SyncPointList: &IXMLSyncPointList{SyncPointCount: "1", SyncPoints: []*IXMLSyncPoint{{SyncPointLow: "441", SyncPointHigh: "0", SyncPointEventDuration: "4410"}}},
+	}
+	createTestPolyFile(t, path, EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), bext, ixml, createTestSine(0.5, 1000, 44100, 4410, 2))
+
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadRiff(in.Name(), in)
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	err := Resample(out, in, container, 48000)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	fr, err := NewFrameReader(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.SampleRate(), 48000, "SampleRate")
+	assertEqual(t, fr.Channels(), 2, "Channels")
+	assertEqual(t, fr.NumFrames(), uint64(4800), "NumFrames")
+
+	format, _ := ReadChunk(out, container.FindHeaders(FMTID)[0])
+	pcmFormat, _ := DecodePCMFormatChunk(format)
+
+	assertEqual(t, pcmFormat.BytesPerSec(), 192000, "BytesPerSec")
+
+	outBext, _ := readBext(out, container)
+
+	assertEqual(t, outBext.TimeReference(), uint64(48000*3600), "TimeReference")
+
+	outIXML, _ := readIXML(out, container)
+
+	assertEqual(t, outIXML.Speed.TimestampSamplesSinceMidnightLo, "172800000", "TimestampSamplesSinceMidnightLo")
+	assertEqual(t, outIXML.Speed.FileSampleRate, "48000", "FileSampleRate")
+	assertEqual(t, outIXML.SyncPointList.SyncPoints[0].SyncPointLow, "480", "SyncPointLow")
+	assertEqual(t, outIXML.SyncPointList.SyncPoints[0].SyncPointEventDuration, "4800", "SyncPointEventDuration")
+
+	samples := make([]float64, 2*4800)
+	fr.ReadSamples(0, 4800, samples)
+
+	for i := 100; i < 4700; i++ {
+		want := 0.5 * math.Sin(2*math.Pi*1000*float64(i)/48000)
+
+		if math.Abs(samples[2*i]-want) > 1e-3 {
+			t.Fatalf("sample %d is %f, want %f", i, samples[2*i], want)
+		}
+	}
+}
+
+func TestResampleAiff(t *testing.T) {
+	dir := t.TempDir()
+	data := createTestSine(0.5, 1000, 96000, 960, 1)
+
+	for i := 0; i < len(data); i += 2 {
+		data[i], data[i+1] = data[i+1], data[i]
+	}
+
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 960, 16, 96000, CreateFourCC("NONE"), ""), 0, data)
+	path := filepath.Join(dir, "in.aiff")
+	os.WriteFile(path, aiff, 0644)
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadAiff(in.Name(), in)
+	out, _ := os.Create(filepath.Join(dir, "out.aiff"))
+	defer out.Close()
+	err := Resample(out, in, container, 48000)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadAiff(out.Name(), out)
+	fr, err := NewFrameReader(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.SampleRate(), 48000, "SampleRate")
+	assertEqual(t, fr.NumFrames(), uint64(480), "NumFrames")
+
+	comm, _ := readCOMM(out, container)
+
+	assertEqual(t, comm.SampleFrames(), 480, "SampleFrames")
+}
+
+func TestResampleCuePoints(t *testing.T) {
+	dir := t.TempDir()
+	riff := appendTestChunk(createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), make([]byte, 2*4410)), createTestCue(441, 4409))
+	path := filepath.Join(dir, "in.wav")
+	os.WriteFile(path, riff, 0644)
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadRiff(in.Name(), in)
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	err := Resample(out, in, container, 48000)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	cue, _ := ReadChunk(out, container.FindHeaders(CUEID)[0])
+
+	assertEqual(t, binary.LittleEndian.Uint32(cue[8:12]), uint32(2), "count")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[16:20]), uint32(480), "position")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[32:36]), uint32(480), "sample offset")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[40:44]), uint32(4799), "position")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[56:60]), uint32(4799), "sample offset")
+}
+
+// createTestCue creates a 'cue ' chunk with a cue point in the data chunk for each position.
+func createTestCue(positions ...uint32) []byte {
+	data := make([]byte, 4+len(positions)*cuePointSize)
+	binary.LittleEndian.PutUint32(data, uint32(len(positions)))
+
+	for i, position := range positions {
+		point := data[4+i*cuePointSize:]
+		binary.LittleEndian.PutUint32(point[0:4], uint32(i+1))
+		binary.LittleEndian.PutUint32(point[4:8], position)
+		copy(point[8:12], DATAID)
+		binary.LittleEndian.PutUint32(point[20:24], position)
+	}
+
+	return append(EncodeChunkHeader(CreateFourCC(CUEID), uint32(len(data)), binary.LittleEndian).Bytes(), data...)
+}
+
+// appendTestChunk appends chunk to a RIFF byte stream and updates the container size.
+func appendTestChunk(riff []byte, chunk []byte) []byte {
+	riff = append(riff, chunk...)
+	binary.LittleEndian.PutUint32(riff[4:8], uint32(len(riff))-HeaderSizeBytes)
+
+	return riff
+}
+
+// createTestSine creates 16 bit little endian samples of a sine for each channel.
+func createTestSine(amplitude float64, frequency int, sampleRate int, frames int, channels int) []byte {
+	data := make([]byte, frames*channels*2)
+
+	for f := 0; f < frames; f++ {
+		value := int16(math.Round(amplitude * math.Sin(2*math.Pi*float64(frequency)*float64(f)/float64(sampleRate)) * 32768))
+
+		for c := 0; c < channels; c++ {
+			binary.LittleEndian.PutUint16(data[(f*channels+c)*2:], uint16(value))
+		}
+	}
+
+	return data
+}
+
+func TestSoundDataSize(t *testing.T) {
+	size, err := soundDataSize(DATAID, 1000, 4)
+
+	assertNil(t, err, "err")
+	assertEqual(t, size, uint32(4000), "size")
+
+	_, err = soundDataSize(DATAID, 1<<30, 4)
+
+	assertNotNil(t, err, "err with 4 GiB")
+
+	size, err = soundDataSize(DATAID, math.MaxUint32/3, 3)
+
+	assertNil(t, err, "err")
+	assertEqual(t, size, uint32(math.MaxUint32), "max. size")
+
+	_, err = soundDataSize(SSNDID, math.MaxUint32/3, 3)
+
+	assertNotNil(t, err, "err with 'SSND' offset and block size exceeding max. size")
+}
@@ -0,0 +1,435 @@
+package chunk
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/metawav/chunk/internal"
+)
+
+// chunkRewriter writes a replacement for the chunk described by header.
+type chunkRewriter func(w *Writer, header *Header) error
+
+// newContainerWriter creates a Writer for a container of the same type as provided container.
+func newContainerWriter(ws io.WriteSeeker, container *Container) (*Writer, error) {
+	if isAiff(container) {
+		return NewAiffWriter(ws, CreateFourCC(container.Header.Format()))
+	}
+
+	return NewRiffWriter(ws)
+}
+
+// ReplaceChunks copies all chunks of provided container to ws as a container of the same type.
+// Each of provided chunks, as returned by the Bytes function of known chunks, replaces the first chunk
+// with the same id, further chunks with this id are dropped. Chunks 'LIST' are matched by id and list type,
+// chunks 'APPL' by id and application signature.
+// Chunks not yet present are appended.
+func ReplaceChunks(ws io.WriteSeeker, reader io.ReaderAt, container *Container, chunks ...[]byte) error {
+	replacements := make(map[string][]byte)
+	var keys []string
+
+	for _, data := range chunks {
+		if len(data) < int(HeaderSizeBytes) {
+			msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+			return errors.New(msg)
+		}
+
+		key := dataKey(data)
+
+		if _, ok := replacements[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		replacements[key] = data
+	}
+
+	written := make(map[string]bool)
+	rewriters := make(map[string]chunkRewriter)
+	replace := func(w *Writer, header *Header) error {
+		key, err := chunkKey(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		data, ok := replacements[key]
+
+		if !ok {
+			return copyChunk(w, reader, header)
+		}
+
+		if written[key] {
+			return nil
+		}
+
+		written[key] = true
+
+		return w.WriteChunk(data)
+	}
+
+	for key := range replacements {
+		rewriters[key[:IDSizeBytes]] = replace
+	}
+
+	w, err := newContainerWriter(ws, container)
+
+	if err != nil {
+		return err
+	}
+
+	err = copyChunks(w, reader, container, rewriters)
+
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if written[key] {
+			continue
+		}
+
+		err = w.WriteChunk(replacements[key])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+// chunkKey returns the id of the chunk described by header, for chunks 'LIST' followed by the list type
+// and for chunks 'APPL' followed by the application signature.
+func chunkKey(reader io.ReaderAt, header *Header) (string, error) {
+	if header.ID() != LISTID && header.ID() != APPLID {
+		return header.ID(), nil
+	}
+
+	listType, err := readListType(reader, header)
+
+	return header.ID() + listType, err
+}
+
+// readListType returns the list type of the chunk 'LIST' or the application signature of the chunk 'APPL'
+// described by header.
+func readListType(reader io.ReaderAt, header *Header) (string, error) {
+	listType := make([]byte, FormatSizeBytes)
+	_, err := reader.ReadAt(listType, int64(header.StartPos()+HeaderSizeBytes))
+
+	return string(listType), err
+}
+
+// findList returns the header of the first chunk 'LIST' of provided list type, nil if not found.
+func findList(reader io.ReaderAt, container *Container, listType string) (*Header, error) {
+	for _, header := range container.FindHeaders(LISTID) {
+		t, err := readListType(reader, header)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if t == listType {
+			return header, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// rewrite copies all chunks of provided container to w and closes w (see copyChunks).
+func rewrite(w *Writer, reader io.ReaderAt, container *Container, rewriters map[string]chunkRewriter) error {
+	err := copyChunks(w, reader, container, rewriters)
+
+	if err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// copyChunks copies all chunks of provided container to w.
+// Chunks with a rewriter for their ID are replaced by the output of the rewriter.
+func copyChunks(w *Writer, reader io.ReaderAt, container *Container, rewriters map[string]chunkRewriter) error {
+	for _, header := range container.Headers {
+		var err error
+
+		if header.ID() == FVERID && w.hasFVER {
+			continue
+		}
+
+		if rewriter, ok := rewriters[header.ID()]; ok {
+			err = rewriter(w, header)
+		} else {
+			err = copyChunk(w, reader, header)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyChunk copies the chunk described by header unchanged to w.
+func copyChunk(w *Writer, reader io.ReaderAt, header *Header) error {
+	var id FourCC
+	binary.BigEndian.PutUint32(id[:], header.id)
+	cw, err := w.CreateChunk(id, header.Size())
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(cw, io.NewSectionReader(reader, int64(header.StartPos()+HeaderSizeBytes), int64(header.Size())))
+
+	return err
+}
+
+// rewriteBext returns a chunkRewriter writing the 'bext' chunk after applying update.
+func rewriteBext(reader io.ReaderAt, update func(b *Bext)) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		b, err := DecodeBextChunk(data)
+
+		if err != nil {
+			return err
+		}
+
+		update(b)
+
+		return w.WriteChunk(b.Bytes())
+	}
+}
+
+// rewriteIXML returns a chunkRewriter writing the 'iXML' chunk after applying update.
+func rewriteIXML(reader io.ReaderAt, update func(c *IXML)) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		c, err := DecodeIXMLChunk(data)
+
+		if err != nil {
+			return err
+		}
+
+		update(c)
+		data, err = c.Bytes()
+
+		if err != nil {
+			return err
+		}
+
+		return w.WriteChunk(data)
+	}
+}
+
+// rewriteCue returns a chunkRewriter writing the 'cue ' chunk with all cue points update returns true for.
+func rewriteCue(reader io.ReaderAt, update func(p *CuePoint) bool) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		c, err := DecodeCueChunk(data)
+
+		if err != nil {
+			return err
+		}
+
+		var points []*CuePoint
+
+		for _, p := range c.Points() {
+			if update(p) {
+				points = append(points, p)
+			}
+		}
+
+		c.SetPoints(points)
+
+		return w.WriteChunk(c.Bytes())
+	}
+}
+
+// rewriteAdtl returns a chunkRewriter writing 'LIST' chunks of type 'adtl' after applying update.
+// Lists of other types are copied unchanged.
+func rewriteAdtl(reader io.ReaderAt, update func(a *Adtl)) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		listType, err := readListType(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		if listType != ADTLID {
+			return copyChunk(w, reader, header)
+		}
+
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		a, err := DecodeAdtlChunk(data)
+
+		if err != nil {
+			return err
+		}
+
+		update(a)
+
+		return w.WriteChunk(a.Bytes())
+	}
+}
+
+// rewriteSmpl returns a chunkRewriter writing the 'smpl' chunk after applying update.
+func rewriteSmpl(reader io.ReaderAt, update func(s *Smpl)) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		s, err := DecodeSmplChunk(data)
+
+		if err != nil {
+			return err
+		}
+
+		update(s)
+
+		return w.WriteChunk(s.Bytes())
+	}
+}
+
+// rewriteMARK returns a chunkRewriter writing the 'MARK' chunk after applying position to all marker positions.
+func rewriteMARK(reader io.ReaderAt, position func(uint64) uint64) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		m, err := DecodeMARKChunk(data)
+
+		if err != nil {
+			return err
+		}
+
+		for _, marker := range m.Markers() {
+			marker.Position = uint32(position(uint64(marker.Position)))
+		}
+
+		return w.WriteChunk(m.Bytes())
+	}
+}
+
+// rewriteCOMM returns a chunkRewriter writing the 'COMM' chunk with provided number of sample frames.
+// Sample rate is updated if greater than 0.
+func rewriteCOMM(reader io.ReaderAt, numFrames uint64, sampleRate int) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		if len(data) < int(HeaderSizeBytes+commAIFFSize) {
+			return copyChunk(w, reader, header)
+		}
+
+		binary.BigEndian.PutUint32(data[HeaderSizeBytes+2:HeaderSizeBytes+6], uint32(numFrames))
+
+		if sampleRate > 0 {
+			sRate := internal.IntToIEEE(uint(sampleRate))
+			copy(data[HeaderSizeBytes+8:HeaderSizeBytes+18], sRate[:])
+		}
+
+		return w.WriteChunk(data)
+	}
+}
+
+// rewriteFact returns a chunkRewriter writing the 'fact' chunk with provided number of sample frames.
+func rewriteFact(reader io.ReaderAt, numFrames uint64) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		data, err := ReadChunk(reader, header)
+
+		if err != nil {
+			return err
+		}
+
+		if len(data) >= int(HeaderSizeBytes)+4 {
+			binary.LittleEndian.PutUint32(data[HeaderSizeBytes:HeaderSizeBytes+4], uint32(numFrames))
+		}
+
+		return w.WriteChunk(data)
+	}
+}
+
+// rewriteMD5 returns a chunkRewriter writing the 'MD5 ' chunk with the MD5 checksum of provided sound data.
+func rewriteMD5(data io.Reader, byteOrder binary.ByteOrder) chunkRewriter {
+	return func(w *Writer, header *Header) error {
+		var digest [md5.Size]byte
+		h := md5.New()
+		_, err := io.Copy(h, data)
+
+		if err != nil {
+			return err
+		}
+
+		copy(digest[:], h.Sum(nil))
+
+		return w.WriteChunk(encodeMD5Chunk(digest, byteOrder).Bytes())
+	}
+}
+
+// dropChunk is a chunkRewriter removing the chunk described by header.
+// It is used for chunks derived from the sound data that are not regenerated.
+func dropChunk(w *Writer, header *Header) error {
+	return nil
+}
+
+// soundDataSize returns the size of sound data chunk with provided id ('data' or 'SSND') of numFrames sample frames
+// without offset and block size of 'SSND'. An error is returned if the chunk size exceeds the max. chunk size.
+func soundDataSize(id string, numFrames uint64, blockAlign int) (uint32, error) {
+	size, err := waveDataSize(numFrames, blockAlign)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if id == SSNDID && size > math.MaxUint32-ssndFieldsSize {
+		msg := fmt.Sprintf("data size %d exceeds max. chunk size %d", uint64(size)+ssndFieldsSize, uint32(math.MaxUint32))
+		return 0, errors.New(msg)
+	}
+
+	return size, nil
+}
+
+// createSoundChunk creates the sound data chunk with provided id ('data' or 'SSND') for size bytes of sample frames.
+// For 'SSND' offset and block size are written with value 0.
+func createSoundChunk(w *Writer, id string, size uint32) (io.Writer, error) {
+	if id != SSNDID {
+		return w.CreateChunk(CreateFourCC(id), size)
+	}
+
+	return w.createSSNDChunk(size, 0)
+}
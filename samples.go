@@ -0,0 +1,156 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ReadSamples reads n sample frames starting at frame start and converts them to interleaved
+// float values in range [-1, 1). dst must provide at least n * Channels values.
+//
+// Supported are integer PCM samples of 8 to 32 bits and IEEE float samples of 32 and 64 bits.
+// The number of frames read is returned. If fewer than n frames are available io.EOF is returned.
+func (fr *FrameReader) ReadSamples(start, n uint64, dst []float64) (int, error) {
+	if uint64(len(dst)) < n*uint64(fr.channels) {
+		msg := fmt.Sprintf("dst requires a minimum length of %d", n*uint64(fr.channels))
+		return 0, errors.New(msg)
+	}
+
+	err := fr.validateSamples()
+
+	if err != nil {
+		return 0, err
+	}
+
+	frames := make([]byte, n*uint64(fr.blockAlign))
+	read, err := fr.ReadFrames(start, n, frames)
+	fr.decodeSamples(frames[:read*fr.blockAlign], dst)
+
+	return read, err
+}
+
+// bytesPerSample is the byte size of a single sample within a frame.
+func (fr *FrameReader) bytesPerSample() int {
+	return fr.blockAlign / fr.channels
+}
+
+// significantBits returns the number of significant bits of a sample. Samples are left-justified,
+// the full sample size is returned if valid bits are not provided by the format.
+func (fr *FrameReader) significantBits() int {
+	bits := 8 * fr.bytesPerSample()
+
+	if fr.validBits > 0 && fr.validBits < bits {
+		return fr.validBits
+	}
+
+	return bits
+}
+
+// validateSamples returns an error if samples can not be converted to float values.
+func (fr *FrameReader) validateSamples() error {
+	bytesPerSample := fr.bytesPerSample()
+
+	switch {
+	case fr.format == wavePCM && bytesPerSample >= 1 && bytesPerSample <= 4:
+		return nil
+	case fr.format == waveIEEEFloat && (bytesPerSample == 4 || bytesPerSample == 8):
+		return nil
+	}
+
+	msg := fmt.Sprintf("unsupported sample format %d with %d bytes per sample", fr.format, bytesPerSample)
+	return errors.New(msg)
+}
+
+// decodeSamples converts frames to interleaved float values.
+func (fr *FrameReader) decodeSamples(frames []byte, dst []float64) {
+	bytesPerSample := fr.bytesPerSample()
+	scale := math.Ldexp(1, 8*bytesPerSample-1)
+	unsigned := bytesPerSample == 1 && fr.unsigned8
+
+	for i := 0; i < len(frames)/bytesPerSample; i++ {
+		sample := frames[i*bytesPerSample : (i+1)*bytesPerSample]
+
+		if fr.format == waveIEEEFloat {
+			if bytesPerSample == 4 {
+				dst[i] = float64(math.Float32frombits(fr.sampleOrder.Uint32(sample)))
+			} else {
+				dst[i] = math.Float64frombits(fr.sampleOrder.Uint64(sample))
+			}
+
+			continue
+		}
+
+		if unsigned {
+			dst[i] = (float64(sample[0]) - 128) / scale
+			continue
+		}
+
+		dst[i] = float64(decodeInt(sample, fr.sampleOrder)) / scale
+	}
+}
+
+// encodeSamples converts interleaved float values to frames. Integer samples are clipped to their range.
+func (fr *FrameReader) encodeSamples(samples []float64, dst []byte) {
+	bytesPerSample := fr.bytesPerSample()
+	scale := math.Ldexp(1, 8*bytesPerSample-1)
+	unsigned := bytesPerSample == 1 && fr.unsigned8
+
+	for i, value := range samples {
+		sample := dst[i*bytesPerSample : (i+1)*bytesPerSample]
+
+		if fr.format == waveIEEEFloat {
+			if bytesPerSample == 4 {
+				fr.sampleOrder.PutUint32(sample, math.Float32bits(float32(value)))
+			} else {
+				fr.sampleOrder.PutUint64(sample, math.Float64bits(value))
+			}
+
+			continue
+		}
+
+		v := math.Round(value * scale)
+		v = math.Max(-scale, math.Min(scale-1, v))
+
+		if unsigned {
+			sample[0] = byte(v + 128)
+			continue
+		}
+
+		encodeInt(int64(v), sample, fr.sampleOrder)
+	}
+}
+
+// decodeInt decodes a signed integer of 1 to 8 bytes.
+func decodeInt(b []byte, byteOrder binary.ByteOrder) int64 {
+	var value uint64
+
+	for i := range b {
+		shift := uint(8 * i)
+
+		if byteOrder == binary.BigEndian {
+			shift = uint(8 * (len(b) - 1 - i))
+		}
+
+		value |= uint64(b[i]) << shift
+	}
+
+	// sign extension
+	bits := uint(64 - 8*len(b))
+
+	return int64(value<<bits) >> bits
+}
+
+// encodeInt encodes a signed integer to len(b) bytes.
+func encodeInt(value int64, b []byte, byteOrder binary.ByteOrder) {
+	for i := range b {
+		shift := uint(8 * i)
+
+		if byteOrder == binary.BigEndian {
+			shift = uint(8 * (len(b) - 1 - i))
+		}
+
+		b[i] = byte(value >> shift)
+	}
+}
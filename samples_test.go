@@ -0,0 +1,86 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestEncodeDecodeSamples(t *testing.T) {
+	samples := []float64{0, 0.5, -0.5, -1, 0.25, -0.125}
+	readers := []*FrameReader{
+		{format: wavePCM, channels: 2, blockAlign: 2, sampleOrder: binary.LittleEndian, unsigned8: true},
+		{format: wavePCM, channels: 2, blockAlign: 2, sampleOrder: binary.LittleEndian},
+		{format: wavePCM, channels: 2, blockAlign: 4, sampleOrder: binary.LittleEndian},
+		{format: wavePCM, channels: 2, blockAlign: 6, sampleOrder: binary.LittleEndian},
+		{format: wavePCM, channels: 2, blockAlign: 6, sampleOrder: binary.BigEndian},
+		{format: wavePCM, channels: 2, blockAlign: 8, sampleOrder: binary.BigEndian},
+		{format: waveIEEEFloat, channels: 2, blockAlign: 8, sampleOrder: binary.LittleEndian},
+		{format: waveIEEEFloat, channels: 2, blockAlign: 16, sampleOrder: binary.BigEndian},
+	}
+
+	for _, fr := range readers {
+		assertNil(t, fr.validateSamples(), "validateSamples")
+
+		data := make([]byte, len(samples)*fr.bytesPerSample())
+		fr.encodeSamples(samples, data)
+		decoded := make([]float64, len(samples))
+		fr.decodeSamples(data, decoded)
+
+		for i := range samples {
+			assertEqual(t, decoded[i], samples[i], "sample")
+		}
+	}
+
+	fr := readers[2]
+	data := make([]byte, 4)
+	fr.encodeSamples([]float64{1, -2}, data)
+
+	assertEqual(t, int16(binary.LittleEndian.Uint16(data[0:2])), int16(math.MaxInt16), "clipped sample")
+	assertEqual(t, int16(binary.LittleEndian.Uint16(data[2:4])), int16(math.MinInt16), "clipped sample")
+
+	fr = &FrameReader{format: 0, channels: 1, blockAlign: 2}
+
+	assertNotNil(t, fr.validateSamples(), "validateSamples of unsupported format")
+}
+
+func TestReadSamples(t *testing.T) {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint16(data[4:6], 0x4000)
+	binary.LittleEndian.PutUint16(data[6:8], 0xC000)
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	fr, _ := NewFrameReader(reader, container)
+	samples := make([]float64, 4)
+	n, err := fr.ReadSamples(0, 2, samples)
+
+	assertNil(t, err, "err")
+	assertEqual(t, n, 2, "frames read")
+	assertEqual(t, samples[2], 0.5, "sample")
+	assertEqual(t, samples[3], -0.5, "sample")
+
+	_, err = fr.ReadSamples(0, 3, samples)
+
+	assertNotNil(t, err, "err with short dst")
+
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 1, 8, 48000, CreateFourCC("NONE"), ""), 0, []byte{0x80})
+	reader = bytes.NewReader(aiff)
+	container, _ = ReadAiff("test", reader)
+	fr, _ = NewFrameReader(reader, container)
+	n, err = fr.ReadSamples(0, 1, samples)
+
+	assertNil(t, err, "err")
+	assertEqual(t, n, 1, "frames read")
+	assertEqual(t, samples[0], -1., "signed 8 bit AIFF sample")
+
+	aiff = createTestAiff(EncodeCOMMChunk(18, 1, 1, 8, 48000, CreateFourCC("sowt"), ""), 0, []byte{0x80})
+	reader = bytes.NewReader(aiff)
+	container, _ = ReadAiff("test", reader)
+	fr, _ = NewFrameReader(reader, container)
+	_, err = fr.ReadSamples(0, 1, samples)
+
+	assertNil(t, err, "err")
+	assertEqual(t, samples[0], -1., "signed 8 bit AIFF-C 'sowt' sample")
+}
@@ -0,0 +1,317 @@
+package chunk
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// DefaultSilenceThreshold is the silence threshold in dBFS used if SilenceOptions.Threshold is not set.
+const DefaultSilenceThreshold = -60.
+
+// SilenceOptions configure silence detection.
+type SilenceOptions struct {
+	// Threshold in dBFS, a frame is silent if the absolute values of all its samples are below.
+	// If 0, DefaultSilenceThreshold is used.
+	Threshold float64
+	// MinDuration is the min. duration of a silent region.
+	MinDuration time.Duration
+}
+
+// SilentRegion is a region of silent sample frames.
+type SilentRegion struct {
+	// Start is the first silent frame.
+	Start uint64
+	// Length is the number of silent frames.
+	Length uint64
+}
+
+// String returns string represensation of silent region.
+func (r *SilentRegion) String() string {
+	return fmt.Sprintf("Start: %d Length: %d", r.Start, r.Length)
+}
+
+// DetectSilence returns all silent regions of the sound data of provided container.
+func DetectSilence(reader io.ReaderAt, container *Container, options SilenceOptions) ([]*SilentRegion, error) {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return detectSilence(fr, options)
+}
+
+func detectSilence(fr *FrameReader, options SilenceOptions) ([]*SilentRegion, error) {
+	err := fr.validateSamples()
+
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := options.Threshold
+
+	if threshold == 0 {
+		threshold = DefaultSilenceThreshold
+	}
+
+	level := math.Pow(10, threshold/20)
+	minFrames := uint64(math.Round(options.MinDuration.Seconds() * float64(fr.SampleRate())))
+	channels := fr.Channels()
+	var regions []*SilentRegion
+	var region *SilentRegion
+	var pos uint64
+
+	addRegion := func() {
+		if region != nil && region.Length >= minFrames && region.Length > 0 {
+			regions = append(regions, region)
+		}
+
+		region = nil
+	}
+
+	samples := make([]float64, 0)
+
+	err = readFrameBlocks(fr, 0, fr.NumFrames(), func(block []byte, frames int) error {
+		if cap(samples) < frames*channels {
+			samples = make([]float64, frames*channels)
+		}
+
+		samples = samples[:frames*channels]
+		fr.decodeSamples(block, samples)
+
+		for f := 0; f < frames; f++ {
+			silent := true
+
+			for _, value := range samples[f*channels : (f+1)*channels] {
+				if math.Abs(value) >= level {
+					silent = false
+					break
+				}
+			}
+
+			if !silent {
+				addRegion()
+			} else if region == nil {
+				region = &SilentRegion{Start: pos + uint64(f), Length: 1}
+			} else {
+				region.Length++
+			}
+		}
+
+		pos += uint64(frames)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	addRegion()
+
+	return regions, nil
+}
+
+// Trim writes length sample frames starting at frame start of provided container to ws as a container of the same type.
+//
+// Sample based metadata is adjusted so sync is preserved: bext time reference and iXML timestamp and time reference
+// are advanced by start, iXML sync points and cue points are moved by start towards the beginning.
+// Cue points outside the trimmed range are removed together with their labels, notes and labeled texts,
+// regions exceeding the range are shortened. Sampler loops are moved, loops not within the range are removed.
+// AIFF markers are moved and limited to the range, as they are referenced by the instrument chunk.
+// Chunk 'MD5 ' is set to the checksum of the trimmed sound data. Peak chunks 'PEAK' and 'levl' are removed
+// as their values and positions no longer match, use WritePeak to regenerate 'PEAK'.
+// All other chunks are copied unchanged.
+func Trim(ws io.WriteSeeker, reader io.ReaderAt, container *Container, start, length uint64) error {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return err
+	}
+
+	return trimFrames(ws, reader, container, fr, start, length)
+}
+
+// TrimSilence removes leading and trailing silence of provided container and writes the result to ws (see Trim).
+// The number of frames removed at head and tail are returned.
+func TrimSilence(ws io.WriteSeeker, reader io.ReaderAt, container *Container, options SilenceOptions) (uint64, uint64, error) {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	regions, err := detectSilence(fr, options)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var head, tail uint64
+	numFrames := fr.NumFrames()
+
+	for _, region := range regions {
+		if region.Start == 0 {
+			head = region.Length
+		}
+
+		if region.Start+region.Length == numFrames && region.Start > 0 {
+			tail = region.Length
+		}
+	}
+
+	return head, tail, trimFrames(ws, reader, container, fr, head, numFrames-head-tail)
+}
+
+func trimFrames(ws io.WriteSeeker, reader io.ReaderAt, container *Container, fr *FrameReader, start, length uint64) error {
+	numFrames := fr.NumFrames()
+
+	if start > numFrames {
+		start = numFrames
+	}
+
+	if length > numFrames-start {
+		length = numFrames - start
+	}
+
+	advance := func(value uint64) uint64 {
+		return value + start
+	}
+
+	shift := func(value uint64) uint64 {
+		if value < start {
+			return 0
+		}
+
+		return value - start
+	}
+
+	unchanged := func(value uint64) uint64 {
+		return value
+	}
+
+	// cue points outside the trimmed range are removed, regions are cut at the end of the range
+	cue, err := readCue(reader, container)
+
+	if err != nil {
+		return err
+	}
+
+	positions := make(map[uint32]uint64)
+
+	for _, marker := range Markers(cue, nil) {
+		if marker.Position >= start && marker.Position <= start+length {
+			positions[marker.ID] = marker.Position
+		}
+	}
+
+	w, err := newContainerWriter(ws, container)
+
+	if err != nil {
+		return err
+	}
+
+	writeFrames := func(id string) chunkRewriter {
+		return func(w *Writer, header *Header) error {
+			size, err := soundDataSize(id, length, fr.BlockAlign())
+
+			if err != nil {
+				return err
+			}
+
+			cw, err := createSoundChunk(w, id, size)
+
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(cw, io.NewSectionReader(reader, fr.DataPos()+int64(start)*int64(fr.BlockAlign()), int64(size)))
+
+			return err
+		}
+	}
+
+	rewriters := map[string]chunkRewriter{
+		COMMID: rewriteCOMM(reader, length, 0),
+		FACTID: rewriteFact(reader, length),
+		BEXTID: rewriteBext(reader, func(b *Bext) {
+			b.SetTimeReference(advance(b.TimeReference()))
+		}),
+		IXMLID: rewriteIXML(reader, func(c *IXML) {
+			c.updateSamples(advance, shift, unchanged)
+		}),
+		CUEID: rewriteCue(reader, func(p *CuePoint) bool {
+			if _, ok := positions[p.ID]; !ok {
+				return false
+			}
+
+			p.Position = uint32(shift(uint64(p.Position)))
+			p.SampleOffset = uint32(shift(uint64(p.SampleOffset)))
+
+			return true
+		}),
+		LISTID: rewriteAdtl(reader, func(a *Adtl) {
+			a.SetLabels(trimLabels(a.Labels(), positions))
+			a.SetNotes(trimLabels(a.Notes(), positions))
+			var labeledTexts []*LabeledText
+
+			for _, t := range a.LabeledTexts() {
+				position, ok := positions[t.CuePointID]
+
+				if !ok {
+					continue
+				}
+
+				if position+uint64(t.SampleLength) > start+length {
+					t.SampleLength = uint32(start + length - position)
+				}
+
+				labeledTexts = append(labeledTexts, t)
+			}
+
+			a.SetLabeledTexts(labeledTexts)
+		}),
+		SMPLID: rewriteSmpl(reader, func(s *Smpl) {
+			var loops []*SampleLoop
+
+			for _, l := range s.Loops() {
+				if uint64(l.Start) >= start && uint64(l.End) < start+length {
+					l.Start = uint32(shift(uint64(l.Start)))
+					l.End = uint32(shift(uint64(l.End)))
+					loops = append(loops, l)
+				}
+			}
+
+			s.SetLoops(loops)
+		}),
+		MARKID: rewriteMARK(reader, func(value uint64) uint64 {
+			if value > start+length {
+				return length
+			}
+
+			return shift(value)
+		}),
+		PEAKID: dropChunk,
+		LEVLID: dropChunk,
+		MD5ID:  rewriteMD5(io.NewSectionReader(reader, fr.DataPos()+int64(start)*int64(fr.BlockAlign()), int64(length)*int64(fr.BlockAlign())), container.ByteOrder),
+		DATAID: writeFrames(DATAID),
+		SSNDID: writeFrames(SSNDID),
+	}
+
+	return rewrite(w, reader, container, rewriters)
+}
+
+// trimLabels returns the labels of cue points in positions.
+func trimLabels(labels []*Label, positions map[uint32]uint64) []*Label {
+	var trimmed []*Label
+
+	for _, l := range labels {
+		if _, ok := positions[l.CuePointID]; ok {
+			trimmed = append(trimmed, l)
+		}
+	}
+
+	return trimmed
+}
@@ -0,0 +1,131 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDetectSilence(t *testing.T) {
+	silence := make([]byte, 4410*2*2)
+	data := append(append(append([]byte{}, silence...), createTestSine(0.5, 1000, 44100, 4410, 2)...), silence[:2205*2*2]...)
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	regions, err := DetectSilence(reader, container, SilenceOptions{MinDuration: 10 * time.Millisecond})
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(regions), 2, "len(regions)")
+	assertEqual(t, regions[0].Start, uint64(0), "Start")
+	assertEqual(t, regions[0].Length, uint64(4411), "Length")
+	assertEqual(t, regions[1].Start, uint64(4410+4410), "Start")
+	assertEqual(t, regions[1].Length, uint64(2205), "Length")
+
+	regions, _ = DetectSilence(reader, container, SilenceOptions{MinDuration: 60 * time.Millisecond})
+
+	assertEqual(t, len(regions), 1, "len(regions)")
+}
+
+func TestTrimSilence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "in.wav")
+	silence := make([]byte, 4410*2)
+	data := append(append(append([]byte{}, silence...), createTestSine(0.5, 1000, 44100, 4410, 1)...), silence...)
+	bext := &Bext{}
+	bext.SetTimeReference(44100 * 3600)
+	ixml := &IXML{
+		Speed:         &IXMLSpeed{TimestampSamplesSinceMidnightHi: "0", TimestampSamplesSinceMidnightLo: "158760000"},
+		SyncPointList: &IXMLSyncPointList{SyncPointCount: "1", SyncPoints: []*IXMLSyncPoint{{SyncPointLow: "8820", SyncPointHigh: "0", SyncPointEventDuration: "100"}}},
+	}
+	createTestPolyFile(t, path, EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), bext, ixml, data)
+
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadRiff(in.Name(), in)
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	head, tail, err := TrimSilence(out, in, container, SilenceOptions{Threshold: -40})
+
+	assertNil(t, err, "err")
+	assertEqual(t, head, uint64(4411), "head")
+	assertEqual(t, tail, uint64(4410), "tail")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	fr, err := NewFrameReader(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.NumFrames(), uint64(4409), "NumFrames")
+
+	frames := make([]byte, 4)
+	fr.ReadFrames(0, 2, frames)
+
+	assertEqual(t, bytes.Equal(frames, data[4411*2:4413*2]), true, "frames")
+
+	outBext, _ := readBext(out, container)
+
+	assertEqual(t, outBext.TimeReference(), uint64(44100*3600+4411), "TimeReference")
+
+	outIXML, _ := readIXML(out, container)
+
+	assertEqual(t, outIXML.Speed.TimestampSamplesSinceMidnightLo, "158764411", "TimestampSamplesSinceMidnightLo")
+	assertEqual(t, outIXML.SyncPointList.SyncPoints[0].SyncPointLow, "4409", "SyncPointLow")
+	assertEqual(t, outIXML.SyncPointList.SyncPoints[0].SyncPointEventDuration, "100", "SyncPointEventDuration")
+}
+
+func TestTrimCuePoints(t *testing.T) {
+	dir := t.TempDir()
+	riff := appendTestChunk(createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), make([]byte, 2*1000)), createTestCue(50, 100, 300, 800))
+	path := filepath.Join(dir, "in.wav")
+	os.WriteFile(path, riff, 0644)
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadRiff(in.Name(), in)
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	err := Trim(out, in, container, 100, 500)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	cue, _ := ReadChunk(out, container.FindHeaders(CUEID)[0])
+
+	assertEqual(t, binary.LittleEndian.Uint32(cue[8:12]), uint32(2), "count")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[12:16]), uint32(2), "ID")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[16:20]), uint32(0), "position")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[36:40]), uint32(3), "ID")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[40:44]), uint32(200), "position")
+	assertEqual(t, binary.LittleEndian.Uint32(cue[56:60]), uint32(200), "sample offset")
+}
+
+func TestTrimAiff(t *testing.T) {
+	dir := t.TempDir()
+	data := createTestSine(0.5, 1000, 44100, 100, 1)
+	aiff := createTestAiff(EncodeCOMMChunk(18, 1, 100, 16, 44100, CreateFourCC("NONE"), ""), 0, data)
+	path := filepath.Join(dir, "in.aiff")
+	os.WriteFile(path, aiff, 0644)
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadAiff(in.Name(), in)
+	out, _ := os.Create(filepath.Join(dir, "out.aiff"))
+	defer out.Close()
+	err := Trim(out, in, container, 10, 50)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadAiff(out.Name(), out)
+	fr, err := NewFrameReader(out, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.NumFrames(), uint64(50), "NumFrames")
+
+	frames := make([]byte, 100)
+	fr.ReadFrames(0, 50, frames)
+
+	assertEqual(t, bytes.Equal(frames, data[20:120]), true, "frames")
+}
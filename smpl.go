@@ -0,0 +1,295 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// LoopForward is the loop type playing from start to end.
+	LoopForward uint32 = 0
+	// LoopPingPong is the loop type playing alternating forward and backward.
+	LoopPingPong uint32 = 1
+	// LoopReverse is the loop type playing from end to start.
+	LoopReverse uint32 = 2
+	// smplSize is the byte size of the smpl chunk data without loops and sampler data.
+	smplSize = 36
+	// sampleLoopSize is the byte size of a sample loop.
+	sampleLoopSize = 24
+)
+
+// SampleLoop is a loop of chunk 'smpl'.
+type SampleLoop struct {
+	// CuePointID identifies the cue point of the loop in chunk 'cue '.
+	CuePointID uint32
+	// Type is LoopForward, LoopPingPong, LoopReverse or a manufacturer specific type.
+	Type uint32
+	// Start is the sample frame of the loop start.
+	Start uint32
+	// End is the sample frame of the loop end, which is played.
+	End uint32
+	// Fraction is the fraction of a sample frame at which to loop, 0x80000000 is half a sample frame.
+	Fraction uint32
+	// PlayCount is the number of times to play the loop, 0 for infinite.
+	PlayCount uint32
+}
+
+// StartSeconds is the loop start in seconds using the sample rate of provided format chunk.
+func (l *SampleLoop) StartSeconds(format *FMT) float64 {
+	return float64(l.Start) / float64(format.SamplesPerSec())
+}
+
+// EndSeconds is the loop end including fraction in seconds using the sample rate of provided format chunk.
+func (l *SampleLoop) EndSeconds(format *FMT) float64 {
+	return (float64(l.End) + float64(l.Fraction)/(1<<32)) / float64(format.SamplesPerSec())
+}
+
+// String returns string represensation of sample loop.
+func (l *SampleLoop) String() string {
+	return fmt.Sprintf("Cue point ID: %d Type: %d Start: %d End: %d Fraction: %d Play count: %d", l.CuePointID, l.Type, l.Start, l.End, l.Fraction, l.PlayCount)
+}
+
+// Smpl is RIFF sampler chunk 'smpl' describing the use of the sound data by a sampler.
+type Smpl struct {
+	*Header
+	manufacturer      uint32
+	product           uint32
+	samplePeriod      uint32
+	midiUnityNote     uint32
+	midiPitchFraction uint32
+	smpteFormat       uint32
+	smpteOffset       uint32
+	loops             []*SampleLoop
+	samplerData       []byte
+}
+
+// Manufacturer is the MMA manufacturer code of the intended sampler, 0 if not specific.
+func (s *Smpl) Manufacturer() uint32 {
+	return s.manufacturer
+}
+
+// SetManufacturer
+func (s *Smpl) SetManufacturer(value uint32) {
+	s.manufacturer = value
+}
+
+// Product is the product code of the intended sampler, 0 if not specific.
+func (s *Smpl) Product() uint32 {
+	return s.product
+}
+
+// SetProduct
+func (s *Smpl) SetProduct(value uint32) {
+	s.product = value
+}
+
+// SamplePeriod is the duration of a sample frame in nanoseconds.
+func (s *Smpl) SamplePeriod() uint32 {
+	return s.samplePeriod
+}
+
+// SetSamplePeriod
+func (s *Smpl) SetSamplePeriod(value uint32) {
+	s.samplePeriod = value
+}
+
+// SetSampleRate sets the sample period of provided sample rate.
+func (s *Smpl) SetSampleRate(sampleRate int) {
+	s.samplePeriod = uint32(math.Round(1e9 / float64(sampleRate)))
+}
+
+// MIDIUnityNote is the MIDI note (0 - 127) playing the sound data at its original pitch, 60 is middle C.
+func (s *Smpl) MIDIUnityNote() uint32 {
+	return s.midiUnityNote
+}
+
+// SetMIDIUnityNote
+func (s *Smpl) SetMIDIUnityNote(value uint32) {
+	s.midiUnityNote = value
+}
+
+// MIDIPitchFraction is the fraction of a semitone above the unity note, 0x80000000 is 50 cents.
+func (s *Smpl) MIDIPitchFraction() uint32 {
+	return s.midiPitchFraction
+}
+
+// SetMIDIPitchFraction
+func (s *Smpl) SetMIDIPitchFraction(value uint32) {
+	s.midiPitchFraction = value
+}
+
+// PitchFractionCents is the pitch fraction in cents.
+func (s *Smpl) PitchFractionCents() float64 {
+	return float64(s.midiPitchFraction) / (1 << 32) * 100
+}
+
+// SMPTEFormat is the SMPTE frame rate of the SMPTE offset: 0 (no offset), 24, 25, 29 (30 drop frame) or 30.
+func (s *Smpl) SMPTEFormat() uint32 {
+	return s.smpteFormat
+}
+
+// SetSMPTEFormat
+func (s *Smpl) SetSMPTEFormat(value uint32) {
+	s.smpteFormat = value
+}
+
+// SMPTEOffset is the SMPTE time of the first sample frame packed as 0xhhmmssff, hours may be negative.
+func (s *Smpl) SMPTEOffset() uint32 {
+	return s.smpteOffset
+}
+
+// SetSMPTEOffset
+func (s *Smpl) SetSMPTEOffset(value uint32) {
+	s.smpteOffset = value
+}
+
+// SMPTEOffsetTime returns hours, minutes, seconds and frames of the SMPTE offset.
+func (s *Smpl) SMPTEOffsetTime() (int, int, int, int) {
+	return int(int8(s.smpteOffset >> 24)), int(s.smpteOffset >> 16 & 0xFF), int(s.smpteOffset >> 8 & 0xFF), int(s.smpteOffset & 0xFF)
+}
+
+// SetSMPTEOffsetTime sets the SMPTE offset of provided hours (-23 - 23), minutes, seconds and frames.
+func (s *Smpl) SetSMPTEOffsetTime(hours int, minutes int, seconds int, frames int) {
+	s.smpteOffset = uint32(uint8(int8(hours)))<<24 | uint32(uint8(minutes))<<16 | uint32(uint8(seconds))<<8 | uint32(uint8(frames))
+}
+
+// Loops returns the sample loops.
+func (s *Smpl) Loops() []*SampleLoop {
+	return s.loops
+}
+
+// SetLoops sets the sample loops.
+func (s *Smpl) SetLoops(loops []*SampleLoop) {
+	s.loops = loops
+}
+
+// SamplerData is sampler specific data.
+func (s *Smpl) SamplerData() []byte {
+	return s.samplerData
+}
+
+// SetSamplerData sets sampler specific data.
+func (s *Smpl) SetSamplerData(value []byte) {
+	s.samplerData = value
+}
+
+// String returns string represensation of chunk.
+func (s *Smpl) String() string {
+	lines := make([]string, len(s.loops))
+
+	for i, l := range s.loops {
+		lines[i] = l.String()
+	}
+
+	return fmt.Sprintf("Manufacturer: %d\nProduct: %d\nSample period: %d\nMIDI unity note: %d\nMIDI pitch fraction: %d\nSMPTE format: %d\nSMPTE offset: %08x\nSample loops: %d\nSampler data: %d\n%s",
+		s.Manufacturer(), s.Product(), s.SamplePeriod(), s.MIDIUnityNote(), s.MIDIPitchFraction(), s.SMPTEFormat(), s.SMPTEOffset(), len(s.loops), len(s.samplerData), strings.Join(lines, "\n"))
+}
+
+// Bytes converts Smpl to byte array. A new Header with id 'smpl' is created.
+//
+// Header size is set to real data size. A minimum amount of 44 bytes is returned.
+// chunk header - 8 bytes
+// manufacturer - 4 bytes
+// product - 4 bytes
+// sample period - 4 bytes
+// MIDI unity note - 4 bytes
+// MIDI pitch fraction - 4 bytes
+// SMPTE format - 4 bytes
+// SMPTE offset - 4 bytes
+// number of sample loops - 4 bytes
+// sampler data size - 4 bytes
+// sample loops - 24 bytes each: cue point id, type, start, end, fraction, play count
+// sampler data - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (s *Smpl) Bytes() []byte {
+	byteOrder := binary.LittleEndian
+	data := make([]byte, smplSize+sampleLoopSize*len(s.loops))
+	values := []uint32{s.manufacturer, s.product, s.samplePeriod, s.midiUnityNote, s.midiPitchFraction,
+		s.smpteFormat, s.smpteOffset, uint32(len(s.loops)), uint32(len(s.samplerData))}
+
+	for _, l := range s.loops {
+		values = append(values, l.CuePointID, l.Type, l.Start, l.End, l.Fraction, l.PlayCount)
+	}
+
+	for i, value := range values {
+		byteOrder.PutUint32(data[4*i:4*i+4], value)
+	}
+
+	data = append(data, s.samplerData...)
+	header := EncodeChunkHeader(CreateFourCC(SMPLID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeSmplChunk returns encoded chunk 'smpl' by provided parameters.
+func EncodeSmplChunk(manufacturer uint32, product uint32, samplePeriod uint32, midiUnityNote uint32, midiPitchFraction uint32, smpteFormat uint32, smpteOffset uint32, loops []*SampleLoop, samplerData []byte) *Smpl {
+	size := uint32(smplSize + sampleLoopSize*len(loops) + len(samplerData))
+	header := EncodeChunkHeader(CreateFourCC(SMPLID), size, binary.LittleEndian)
+
+	return &Smpl{Header: header, manufacturer: manufacturer, product: product, samplePeriod: samplePeriod, midiUnityNote: midiUnityNote,
+		midiPitchFraction: midiPitchFraction, smpteFormat: smpteFormat, smpteOffset: smpteOffset, loops: loops, samplerData: samplerData}
+}
+
+// DecodeSmplChunk decodes provided byte array to Smpl.
+//
+// Array content should be:
+// chunk header - 8 bytes (min. requirement for successful decoding)
+// data - 36 bytes
+// sample loops - 24 bytes each
+// sampler data - not restricted amount of bytes
+func DecodeSmplChunk(data []byte) (*Smpl, error) {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return nil, errors.New(msg)
+	}
+
+	s := &Smpl{}
+	byteOrder := binary.LittleEndian
+	s.Header = decodeChunkHeader(data[:HeaderSizeBytes], 0, byteOrder)
+	buf := bytes.NewReader(data[HeaderSizeBytes:])
+	var numLoops, samplerDataSize uint32
+	fields := []interface{}{&s.manufacturer, &s.product, &s.samplePeriod, &s.midiUnityNote, &s.midiPitchFraction,
+		&s.smpteFormat, &s.smpteOffset, &numLoops, &samplerDataSize}
+
+	for _, f := range fields {
+		err := binary.Read(buf, byteOrder, f)
+
+		if err != nil {
+			return s, err
+		}
+	}
+
+	for i := uint32(0); i < numLoops; i++ {
+		l := &SampleLoop{}
+		fields := []interface{}{&l.CuePointID, &l.Type, &l.Start, &l.End, &l.Fraction, &l.PlayCount}
+
+		for _, f := range fields {
+			err := binary.Read(buf, byteOrder, f)
+
+			if err != nil {
+				return s, err
+			}
+		}
+
+		s.loops = append(s.loops, l)
+	}
+
+	if int(samplerDataSize) > buf.Len() {
+		samplerDataSize = uint32(buf.Len())
+	}
+
+	s.samplerData = make([]byte, samplerDataSize)
+	err := binary.Read(buf, byteOrder, &s.samplerData)
+
+	if err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
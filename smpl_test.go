@@ -0,0 +1,78 @@
+package chunk
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncodeSmplChunk(t *testing.T) {
+	loops := []*SampleLoop{{CuePointID: 1, Type: LoopPingPong, Start: 100, End: 199, Fraction: 0x80000000, PlayCount: 2}}
+	chunk := EncodeSmplChunk(71, 2, 22676, 60, 0x80000000, 25, 0, loops, []byte{1, 2, 3})
+
+	assertEqual(t, chunk.ID(), SMPLID, "ID")
+	assertEqual(t, chunk.Size(), uint32(36+24+3), "Size")
+	assertEqual(t, len(chunk.Bytes()), 8+36+24+3+1, "Bytes length")
+	assertEqual(t, chunk.PitchFractionCents(), 50., "PitchFractionCents")
+
+	chunk.SetSMPTEOffsetTime(-1, 2, 3, 4)
+	hours, minutes, seconds, frames := chunk.SMPTEOffsetTime()
+
+	assertEqual(t, chunk.SMPTEOffset(), uint32(0xFF020304), "SMPTEOffset")
+	assertEqual(t, [4]int{hours, minutes, seconds, frames}, [4]int{-1, 2, 3, 4}, "SMPTEOffsetTime")
+
+	chunk.SetSampleRate(44100)
+
+	assertEqual(t, chunk.SamplePeriod(), uint32(22676), "SamplePeriod")
+
+	format := EncodeFMTChunk(16, 1, 1, 100, 200, 2)
+
+	assertEqual(t, loops[0].StartSeconds(format), 1., "StartSeconds")
+	assertEqual(t, loops[0].EndSeconds(format), 1.995, "EndSeconds")
+}
+
+func TestDecodeSmplChunk(t *testing.T) {
+	loops := []*SampleLoop{{CuePointID: 1, Type: LoopForward, Start: 10, End: 20}, {CuePointID: 2, Type: LoopReverse, Start: 30, End: 40, PlayCount: 3}}
+	data := EncodeSmplChunk(1, 2, 3, 4, 5, 30, 6, loops, []byte{9}).Bytes()
+	chunk, err := DecodeSmplChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, chunk.Manufacturer(), uint32(1), "Manufacturer")
+	assertEqual(t, chunk.Product(), uint32(2), "Product")
+	assertEqual(t, chunk.SamplePeriod(), uint32(3), "SamplePeriod")
+	assertEqual(t, chunk.MIDIUnityNote(), uint32(4), "MIDIUnityNote")
+	assertEqual(t, chunk.MIDIPitchFraction(), uint32(5), "MIDIPitchFraction")
+	assertEqual(t, chunk.SMPTEFormat(), uint32(30), "SMPTEFormat")
+	assertEqual(t, chunk.SMPTEOffset(), uint32(6), "SMPTEOffset")
+	assertEqual(t, len(chunk.Loops()), 2, "Loops length")
+	assertEqual(t, *chunk.Loops()[1], *loops[1], "Loop")
+	assertEqual(t, bytes.Equal(chunk.SamplerData(), []byte{9}), true, "SamplerData")
+
+	_, err = DecodeSmplChunk(data[:HeaderSizeBytes+36+10])
+
+	assertNotNil(t, err, "err with incomplete loop")
+}
+
+func TestTrimSmpl(t *testing.T) {
+	dir := t.TempDir()
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), createTestSine(0.5, 1000, 44100, 1000, 1))
+	loops := []*SampleLoop{{CuePointID: 1, Start: 100, End: 199}, {CuePointID: 2, Start: 500, End: 899}}
+	riff = append(riff, EncodeSmplChunk(0, 0, 22676, 60, 0, 0, 0, loops, nil).Bytes()...)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	err := Trim(out, reader, container, 400, 600)
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	data, _ := ReadChunk(out, container.FindHeaders(SMPLID)[0])
+	chunk, _ := DecodeSmplChunk(data)
+
+	assertEqual(t, len(chunk.Loops()), 1, "Loops length")
+	assertEqual(t, chunk.Loops()[0].Start, uint32(100), "Start")
+	assertEqual(t, chunk.Loops()[0].End, uint32(499), "End")
+}
@@ -0,0 +1,317 @@
+package chunk
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/metawav/chunk/internal"
+)
+
+const (
+	// DefaultSpectrogramWindowSize is the window size used if SpectrogramOptions.WindowSize is not set.
+	DefaultSpectrogramWindowSize = 2048
+	// DefaultSpectrogramRange is the dB range used if SpectrogramOptions.Range is not set.
+	DefaultSpectrogramRange = 120.
+	// DefaultSpectrogramMaxWidth is the max. image width used if SpectrogramOptions.MaxWidth is not set.
+	DefaultSpectrogramMaxWidth = 4096
+)
+
+// spectrogramColors are the colors of the spectrogram from min. to max. level.
+var spectrogramColors = []color.RGBA{
+	{0, 0, 0, 255},
+	{0, 0, 128, 255},
+	{128, 0, 160, 255},
+	{224, 32, 32, 255},
+	{255, 192, 0, 255},
+	{255, 255, 255, 255},
+}
+
+// SpectrogramOptions configure spectrogram rendering.
+type SpectrogramOptions struct {
+	// WindowSize is the number of sample frames of a Hann window analysed by FFT, a power of 2.
+	// If 0, DefaultSpectrogramWindowSize is used.
+	WindowSize int
+	// Hop is the number of sample frames between two windows, each window is rendered as one pixel column.
+	// If 0, a quarter of WindowSize is used.
+	Hop int
+	// Height is the image height in pixels. If 0, half of WindowSize is used.
+	Height int
+	// MaxWidth is the max. image width in pixels. If there are more windows, adjacent windows are combined
+	// into one pixel column keeping the max. level. If 0, DefaultSpectrogramMaxWidth is used.
+	MaxWidth int
+	// LogFrequency renders the frequency axis in logarithmic instead of linear scale.
+	LogFrequency bool
+	// Range is the dB range below full scale rendered. If 0, DefaultSpectrogramRange is used.
+	Range float64
+	// Channel is the channel rendered starting at 0.
+	Channel int
+	// Mix renders the mix of all channels instead of a single channel.
+	Mix bool
+}
+
+// RenderSpectrogram renders the spectrogram of the sound data of provided container using a short-time Fourier transform.
+// Time runs from left to right, frequency from bottom (0 Hz) to top (Nyquist frequency).
+// A full scale sine is rendered at 0 dB. Sound data is read sequentially in blocks.
+func RenderSpectrogram(reader io.ReaderAt, container *Container, options SpectrogramOptions) (*image.RGBA, error) {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = fr.validateSamples()
+
+	if err != nil {
+		return nil, err
+	}
+
+	options = spectrogramDefaults(options)
+	size := options.WindowSize
+
+	if size < 2 || size&(size-1) != 0 {
+		msg := fmt.Sprintf("window size %d is not a power of 2", size)
+		return nil, errors.New(msg)
+	}
+
+	if options.Hop <= 0 || options.Height <= 0 || options.MaxWidth <= 0 || options.Range <= 0 {
+		return nil, errors.New("hop, height, max. width and range must be greater than 0")
+	}
+
+	channels := fr.Channels()
+
+	if !options.Mix && (options.Channel < 0 || options.Channel >= channels) {
+		msg := fmt.Sprintf("channel %d not in range of %d channels", options.Channel, channels)
+		return nil, errors.New(msg)
+	}
+
+	numFrames := fr.NumFrames()
+	hop := uint64(options.Hop)
+	columns := (numFrames + hop - 1) / hop
+	width := columns
+
+	if width > uint64(options.MaxWidth) {
+		width = uint64(options.MaxWidth)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, int(width), options.Height))
+	bins := spectrogramBins(options, fr.SampleRate())
+	window := internal.Hann(size)
+	var windowSum float64
+
+	for _, w := range window {
+		windowSum += w
+	}
+
+	re := make([]float64, size)
+	im := make([]float64, size)
+	levels := make([]float64, options.Height)
+	x := uint64(0)
+
+	for y := range levels {
+		levels[y] = math.Inf(-1)
+	}
+
+	flush := func() {
+		for y, level := range levels {
+			img.SetRGBA(int(x), options.Height-1-y, spectrogramColor((level+options.Range)/options.Range))
+			levels[y] = math.Inf(-1)
+		}
+	}
+
+	// signal contains the rendered samples from position signalStart. Positions are shifted by half a window
+	// of leading zeros, so windows are centered on their column position.
+	signal := make([]float64, size/2, 2*size)
+	var signalStart, column uint64
+
+	render := func(final bool) {
+		for ; column < columns; column++ {
+			start := column * hop
+			end := start + uint64(size)
+
+			if signalStart+uint64(len(signal)) < end {
+				if !final {
+					break
+				}
+
+				// windows exceeding the sound data are padded with zeros
+				signal = append(signal, make([]float64, end-signalStart-uint64(len(signal)))...)
+			}
+
+			for i := range re {
+				re[i] = signal[start-signalStart+uint64(i)] * window[i]
+				im[i] = 0
+			}
+
+			internal.FFT(re, im)
+
+			if pixel := column * width / columns; pixel != x {
+				flush()
+				x = pixel
+			}
+
+			for y, bin := range bins {
+				var magnitude float64
+
+				for k := bin[0]; k < bin[1]; k++ {
+					magnitude = math.Max(magnitude, math.Hypot(re[k], im[k]))
+				}
+
+				levels[y] = math.Max(levels[y], 20*math.Log10(2*magnitude/windowSum))
+			}
+		}
+
+		// samples before the next window are no longer needed
+		if next := column * hop; next > signalStart {
+			drop := next - signalStart
+
+			if drop > uint64(len(signal)) {
+				drop = uint64(len(signal))
+			}
+
+			signal = append(signal[:0], signal[drop:]...)
+			signalStart += drop
+		}
+	}
+
+	samples := make([]float64, 0)
+
+	err = readFrameBlocks(fr, 0, numFrames, func(block []byte, frames int) error {
+		if cap(samples) < frames*channels {
+			samples = make([]float64, frames*channels)
+		}
+
+		samples = samples[:frames*channels]
+		fr.decodeSamples(block, samples)
+
+		for i := 0; i < frames; i++ {
+			signal = append(signal, spectrogramSample(samples[i*channels:(i+1)*channels], options))
+		}
+
+		render(false)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	render(true)
+
+	if columns > 0 {
+		flush()
+	}
+
+	return img, nil
+}
+
+// WriteSpectrogramPNG renders the spectrogram of the sound data of provided container (see RenderSpectrogram)
+// and writes it to w as PNG.
+func WriteSpectrogramPNG(w io.Writer, reader io.ReaderAt, container *Container, options SpectrogramOptions) error {
+	img, err := RenderSpectrogram(reader, container, options)
+
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+func spectrogramDefaults(options SpectrogramOptions) SpectrogramOptions {
+	if options.WindowSize == 0 {
+		options.WindowSize = DefaultSpectrogramWindowSize
+	}
+
+	if options.Hop == 0 {
+		options.Hop = options.WindowSize / 4
+	}
+
+	if options.Height == 0 {
+		options.Height = options.WindowSize / 2
+	}
+
+	if options.MaxWidth == 0 {
+		options.MaxWidth = DefaultSpectrogramMaxWidth
+	}
+
+	if options.Range == 0 {
+		options.Range = DefaultSpectrogramRange
+	}
+
+	return options
+}
+
+// spectrogramBins returns the range of FFT bins [first, last) of each pixel row starting at the bottom.
+func spectrogramBins(options SpectrogramOptions, sampleRate int) [][2]int {
+	maxBin := float64(options.WindowSize / 2)
+	bins := make([][2]int, options.Height)
+	edge := func(y int) float64 {
+		position := float64(y) / float64(options.Height)
+
+		if options.LogFrequency {
+			// lowest row starts at the first bin above 0 Hz
+			return math.Pow(maxBin, position)
+		}
+
+		return position * maxBin
+	}
+
+	for y := range bins {
+		first := int(math.Round(edge(y)))
+		last := int(math.Round(edge(y + 1)))
+
+		if last <= first {
+			last = first + 1
+		}
+
+		if last > int(maxBin)+1 {
+			last = int(maxBin) + 1
+		}
+
+		bins[y] = [2]int{first, last}
+	}
+
+	return bins
+}
+
+// spectrogramSample returns the sample of the frame rendered.
+func spectrogramSample(frame []float64, options SpectrogramOptions) float64 {
+	if !options.Mix {
+		return frame[options.Channel]
+	}
+
+	var sum float64
+
+	for _, value := range frame {
+		sum += value
+	}
+
+	return sum / float64(len(frame))
+}
+
+// spectrogramColor returns the color of a level in range [0, 1] interpolating spectrogramColors.
+func spectrogramColor(level float64) color.RGBA {
+	if math.IsNaN(level) || level <= 0 {
+		return spectrogramColors[0]
+	}
+
+	if level >= 1 {
+		return spectrogramColors[len(spectrogramColors)-1]
+	}
+
+	position := level * float64(len(spectrogramColors)-1)
+	i := int(position)
+	frac := position - float64(i)
+	a := spectrogramColors[i]
+	b := spectrogramColors[i+1]
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + frac*(float64(y)-float64(x))))
+	}
+
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 255}
+}
@@ -0,0 +1,62 @@
+package chunk
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestRenderSpectrogram(t *testing.T) {
+	data := createTestSine(0.9, 1000, 44100, 44100, 2)
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	img, err := RenderSpectrogram(reader, container, SpectrogramOptions{WindowSize: 1024, Hop: 441, Channel: 1})
+
+	assertNil(t, err, "err")
+	assertEqual(t, img.Bounds().Dx(), 100, "width")
+	assertEqual(t, img.Bounds().Dy(), 512, "height")
+
+	// 1000 Hz is in bin 23
+	peak := img.RGBAAt(50, 511-23)
+	floor := img.RGBAAt(50, 511-300)
+
+	assertEqual(t, peak.R > 200 && peak.G > 200, true, "peak color")
+	assertEqual(t, floor.R < 64 && floor.G < 64, true, "noise floor color")
+
+	img, err = RenderSpectrogram(reader, container, SpectrogramOptions{WindowSize: 1024, Hop: 441, Channel: 1, MaxWidth: 30})
+
+	assertNil(t, err, "err")
+	assertEqual(t, img.Bounds().Dx(), 30, "width limited to MaxWidth")
+	assertEqual(t, img.RGBAAt(15, 511-23), peak, "peak color of combined columns")
+
+	img, err = RenderSpectrogram(reader, container, SpectrogramOptions{WindowSize: 1024, Height: 100, LogFrequency: true, Mix: true})
+
+	assertNil(t, err, "err")
+	assertEqual(t, img.Bounds().Dy(), 100, "height")
+
+	_, err = RenderSpectrogram(reader, container, SpectrogramOptions{WindowSize: 1000})
+
+	assertNotNil(t, err, "err with invalid window size")
+
+	_, err = RenderSpectrogram(reader, container, SpectrogramOptions{Channel: 2})
+
+	assertNotNil(t, err, "err with invalid channel")
+}
+
+func TestWriteSpectrogramPNG(t *testing.T) {
+	data := createTestSine(0.5, 1000, 8000, 8000, 1)
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 1, 8000, 16000, 2, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	buf := &bytes.Buffer{}
+	err := WriteSpectrogramPNG(buf, reader, container, SpectrogramOptions{WindowSize: 256})
+
+	assertNil(t, err, "err")
+
+	img, err := png.Decode(buf)
+
+	assertNil(t, err, "err")
+	assertEqual(t, img.Bounds().Dx(), 125, "width")
+	assertEqual(t, img.Bounds().Dy(), 128, "height")
+}
@@ -0,0 +1,543 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TrackNames returns a track name for each channel of a polyphonic BWF.
+// Names are taken from iXML TRACK_LIST matching INTERLEAVE_INDEX with the channel number (starting at 1).
+// Channels without a named track are named 'ch<channel number>'.
+func TrackNames(reader io.ReaderAt, container *Container) ([]string, error) {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ixml, err := readIXML(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := channelTracks(ixml, fr.Channels())
+	names := make([]string, fr.Channels())
+
+	for i, track := range tracks {
+		names[i] = track.Name
+	}
+
+	return names, nil
+}
+
+// SplitPoly splits a polyphonic BWF into mono BWFs, one for each channel written to the writer of the same index.
+//
+// Samples of AIFF containers are converted to WAVE samples, compressed AIFF-C samples are not supported.
+// Chunks 'bext' and 'iXML' are copied to each mono file, so time reference is kept identical.
+// iXML TRACK_LIST is reduced to the track of the channel and 'sTRK' lines of the bext description
+// are replaced by the name of the track.
+func SplitPoly(reader io.ReaderAt, container *Container, writers []io.WriteSeeker) error {
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return err
+	}
+
+	if len(writers) != fr.Channels() {
+		msg := fmt.Sprintf("%d writers provided for %d channels", len(writers), fr.Channels())
+		return errors.New(msg)
+	}
+
+	err = validateWaveSamples(fr, container)
+
+	if err != nil {
+		return err
+	}
+
+	bext, err := readBext(reader, container)
+
+	if err != nil {
+		return err
+	}
+
+	ixml, err := readIXML(reader, container)
+
+	if err != nil {
+		return err
+	}
+
+	tracks := channelTracks(ixml, fr.Channels())
+	bytesPerSample := fr.BlockAlign() / fr.Channels()
+	dataSize, err := waveDataSize(fr.NumFrames(), bytesPerSample)
+
+	if err != nil {
+		return err
+	}
+
+	format := EncodePCMFormatChunk(16, uint16(fr.Format()), 1, uint32(fr.SampleRate()), uint32(fr.SampleRate()*bytesPerSample), uint16(bytesPerSample), uint16(fr.BitsPerSample()))
+	monoWriters := make([]*Writer, len(writers))
+	dataWriters := make([]io.Writer, len(writers))
+
+	for i, ws := range writers {
+		w, err := NewRiffWriter(ws)
+
+		if err != nil {
+			return err
+		}
+
+		err = w.WriteChunk(format.Bytes())
+
+		if err != nil {
+			return err
+		}
+
+		if bext != nil {
+			bext.SetDescription(trackDescription(bext.Description(), []string{tracks[i].Name}))
+			err = w.WriteChunk(bext.Bytes())
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if ixml != nil {
+			track := *tracks[i]
+			track.InterleaveIndex = "1"
+			ixml.TrackList = &IXMLTrackList{TrackCount: "1", Tracks: []*IXMLTrack{&track}}
+			data, err := ixml.Bytes()
+
+			if err != nil {
+				return err
+			}
+
+			err = w.WriteChunk(data)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		dataWriters[i], err = w.CreateChunk(CreateFourCC(DATAID), dataSize)
+
+		if err != nil {
+			return err
+		}
+
+		monoWriters[i] = w
+	}
+
+	channelData := make([][]byte, fr.Channels())
+
+	err = readFrameBlocks(fr, 0, fr.NumFrames(), func(block []byte, frames int) error {
+		for c := range channelData {
+			channelData[c] = channelData[c][:0]
+		}
+
+		waveSamples(fr, block[:frames*fr.BlockAlign()])
+
+		for f := 0; f < frames; f++ {
+			frame := block[f*fr.BlockAlign():]
+
+			for c := range channelData {
+				channelData[c] = append(channelData[c], frame[c*bytesPerSample:(c+1)*bytesPerSample]...)
+			}
+		}
+
+		for c, data := range channelData {
+			_, err := dataWriters[c].Write(data)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, w := range monoWriters {
+		err = w.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MergeMono merges mono BWFs into one polyphonic BWF written to provided writer, the inverse of SplitPoly.
+// All mono files must have the same format, length and bext time reference, channels are interleaved in the order provided.
+// Chunk 'bext' must be present in all or none of the mono files.
+// Samples of AIFF files are converted to WAVE samples.
+//
+// Chunks 'bext' and 'iXML' are taken from the first mono file. iXML TRACK_LIST is assembled from
+// the tracks of all mono files and 'sTRK' lines of the bext description are set for all tracks.
+func MergeMono(ws io.WriteSeeker, readers []io.ReaderAt, containers []*Container) error {
+	if len(readers) == 0 || len(readers) != len(containers) {
+		return errors.New("a reader is required for each container")
+	}
+
+	frameReaders := make([]*FrameReader, len(readers))
+	tracks := make([]*IXMLTrack, len(readers))
+	var bext *Bext
+	var ixml *IXML
+
+	for i, reader := range readers {
+		fr, err := NewFrameReader(reader, containers[i])
+
+		if err != nil {
+			return err
+		}
+
+		first := frameReaders[0]
+
+		if first != nil && (fr.Format() != first.Format() || fr.SampleRate() != first.SampleRate() || fr.BitsPerSample() != first.BitsPerSample() || fr.BlockAlign() != first.BlockAlign() || fr.NumFrames() != first.NumFrames()) {
+			msg := fmt.Sprintf("format or length of '%s' differs from '%s'", containers[i].Name, containers[0].Name)
+			return errors.New(msg)
+		}
+
+		if fr.Channels() != 1 {
+			msg := fmt.Sprintf("'%s' is not a mono file", containers[i].Name)
+			return errors.New(msg)
+		}
+
+		err = validateWaveSamples(fr, containers[i])
+
+		if err != nil {
+			return err
+		}
+
+		frameReaders[i] = fr
+		monoIXML, err := readIXML(reader, containers[i])
+
+		if err != nil {
+			return err
+		}
+
+		track := channelTracks(monoIXML, 1)[0]
+		track.InterleaveIndex = strconv.Itoa(i + 1)
+		tracks[i] = track
+		monoBext, err := readBext(reader, containers[i])
+
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			ixml = monoIXML
+			bext = monoBext
+			continue
+		}
+
+		// chunk 'bext' missing in some of the files is a mismatch as well
+		if (bext == nil) != (monoBext == nil) || bext != nil && monoBext.TimeReference() != bext.TimeReference() {
+			msg := fmt.Sprintf("time reference of '%s' differs from '%s'", containers[i].Name, containers[0].Name)
+			return errors.New(msg)
+		}
+	}
+
+	channels := len(frameReaders)
+	bytesPerSample := frameReaders[0].BlockAlign()
+	sampleRate := frameReaders[0].SampleRate()
+	numFrames := frameReaders[0].NumFrames()
+	dataSize, err := waveDataSize(numFrames, bytesPerSample*channels)
+
+	if err != nil {
+		return err
+	}
+
+	format := EncodePCMFormatChunk(16, uint16(frameReaders[0].Format()), uint16(channels), uint32(sampleRate), uint32(sampleRate*bytesPerSample*channels), uint16(bytesPerSample*channels), uint16(frameReaders[0].BitsPerSample()))
+	w, err := NewRiffWriter(ws)
+
+	if err != nil {
+		return err
+	}
+
+	err = w.WriteChunk(format.Bytes())
+
+	if err != nil {
+		return err
+	}
+
+	if bext != nil {
+		names := make([]string, channels)
+
+		for i, track := range tracks {
+			names[i] = track.Name
+		}
+
+		bext.SetDescription(trackDescription(bext.Description(), names))
+		err = w.WriteChunk(bext.Bytes())
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if ixml != nil {
+		ixml.TrackList = &IXMLTrackList{TrackCount: strconv.Itoa(channels), Tracks: tracks}
+		data, err := ixml.Bytes()
+
+		if err != nil {
+			return err
+		}
+
+		err = w.WriteChunk(data)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	dataWriter, err := w.CreateChunk(CreateFourCC(DATAID), dataSize)
+
+	if err != nil {
+		return err
+	}
+
+	const blockFrames = 4096
+	channelData := make([]byte, blockFrames*bytesPerSample)
+	data := make([]byte, blockFrames*bytesPerSample*channels)
+
+	for start := uint64(0); start < numFrames; start += blockFrames {
+		n := uint64(blockFrames)
+
+		if numFrames-start < n {
+			n = numFrames - start
+		}
+
+		for c, fr := range frameReaders {
+			_, err = fr.ReadFrames(start, n, channelData)
+
+			if err != nil {
+				return err
+			}
+
+			waveSamples(fr, channelData[:int(n)*bytesPerSample])
+
+			for f := 0; f < int(n); f++ {
+				pos := (f*channels + c) * bytesPerSample
+				copy(data[pos:pos+bytesPerSample], channelData[f*bytesPerSample:(f+1)*bytesPerSample])
+			}
+		}
+
+		_, err = dataWriter.Write(data[:int(n)*bytesPerSample*channels])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+// validateWaveSamples returns an error if the samples of fr can not be written to WAVE, e.g. compressed AIFF-C samples.
+func validateWaveSamples(fr *FrameReader, container *Container) error {
+	if fr.Format() == 0 {
+		msg := fmt.Sprintf("unsupported compressed sample data of '%s'", container.Name)
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// waveSamples converts provided frames of fr in place to WAVE samples: little-endian and unsigned if 8 bit.
+func waveSamples(fr *FrameReader, frames []byte) {
+	bytesPerSample := fr.bytesPerSample()
+
+	if bytesPerSample == 1 && !fr.unsigned8 {
+		for i := range frames {
+			frames[i] ^= 0x80
+		}
+
+		return
+	}
+
+	if fr.sampleOrder != binary.BigEndian {
+		return
+	}
+
+	for pos := 0; pos+bytesPerSample <= len(frames); pos += bytesPerSample {
+		sample := frames[pos : pos+bytesPerSample]
+
+		for i, j := 0, len(sample)-1; i < j; i, j = i+1, j-1 {
+			sample[i], sample[j] = sample[j], sample[i]
+		}
+	}
+}
+
+// waveDataSize returns the size of chunk 'data' of numFrames frames, an error if it exceeds the max. chunk size.
+func waveDataSize(numFrames uint64, blockAlign int) (uint32, error) {
+	size := numFrames * uint64(blockAlign)
+
+	if size > math.MaxUint32 {
+		msg := fmt.Sprintf("data size %d exceeds max. chunk size %d", size, uint32(math.MaxUint32))
+		return 0, errors.New(msg)
+	}
+
+	return uint32(size), nil
+}
+
+// SplitPolyFile splits the polyphonic BWF or AIFF file at provided path into mono BWFs written to directory dir.
+// Files are named '<name>_<track name>.wav', the paths of the created files are returned.
+func SplitPolyFile(path string, dir string) ([]string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+	container, err := readContainer(file.Name(), file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := TrackNames(file, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	paths := make([]string, len(names))
+	writers := make([]io.WriteSeeker, len(names))
+	used := make(map[string]bool)
+
+	for i, name := range names {
+		fileName := fmt.Sprintf("%s_%s", base, fileNameOf(name))
+
+		if used[fileName] {
+			fileName = fmt.Sprintf("%s_%d", fileName, i+1)
+		}
+
+		used[fileName] = true
+		paths[i] = filepath.Join(dir, fileName+".wav")
+		monoFile, err := os.Create(paths[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer monoFile.Close()
+		writers[i] = monoFile
+	}
+
+	return paths, SplitPoly(file, container, writers)
+}
+
+// MergeMonoFiles merges the mono BWF or AIFF files at provided paths into one polyphonic BWF written to path.
+func MergeMonoFiles(path string, paths []string) error {
+	readers := make([]io.ReaderAt, len(paths))
+	containers := make([]*Container, len(paths))
+
+	for i, monoPath := range paths {
+		file, err := os.Open(monoPath)
+
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+		container, err := readContainer(file.Name(), file)
+
+		if err != nil {
+			return err
+		}
+
+		readers[i] = file
+		containers[i] = container
+	}
+
+	file, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return MergeMono(file, readers, containers)
+}
+
+// channelTracks returns a copy of the iXML track for each channel.
+// A track is assigned by INTERLEAVE_INDEX or by position in TRACK_LIST if no index is set.
+func channelTracks(ixml *IXML, channels int) []*IXMLTrack {
+	tracks := make([]*IXMLTrack, channels)
+
+	if ixml != nil && ixml.TrackList != nil {
+		for i, track := range ixml.TrackList.Tracks {
+			channel := i
+
+			if track.InterleaveIndex != "" {
+				index, err := strconv.Atoi(strings.TrimSpace(track.InterleaveIndex))
+
+				if err != nil {
+					continue
+				}
+
+				channel = index - 1
+			}
+
+			if channel >= 0 && channel < channels && tracks[channel] == nil {
+				t := *track
+				tracks[channel] = &t
+			}
+		}
+	}
+
+	for i, track := range tracks {
+		if track == nil {
+			tracks[i] = &IXMLTrack{ChannelIndex: strconv.Itoa(i + 1), InterleaveIndex: strconv.Itoa(i + 1)}
+		}
+
+		if tracks[i].Name == "" {
+			tracks[i].Name = fmt.Sprintf("ch%d", i+1)
+		}
+	}
+
+	return tracks
+}
+
+// trackDescription replaces 'sTRK<n>=' lines of a bext description by lines for provided track names.
+func trackDescription(description string, names []string) string {
+	var lines []string
+
+	for _, line := range strings.Split(strings.ReplaceAll(description, "\r\n", "\n"), "\n") {
+		if line == "" || strings.HasPrefix(line, "sTRK") {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	for i, name := range names {
+		lines = append(lines, fmt.Sprintf("sTRK%d=%s", i+1, name))
+	}
+
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+// fileNameOf replaces characters not suitable for file names.
+func fileNameOf(name string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>| `, r) {
+			return '_'
+		}
+
+		return r
+	}, name)
+}
@@ -0,0 +1,215 @@
+package chunk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSplitPolyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "poly.wav")
+	data := make([]byte, 3*2*100)
+
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	bext := &Bext{}
+	bext.SetDescription("sSCENE=1\r\nsTRK1=Boom\r\nsTRK2=Lav1\r\nsTRK3=Lav2\r\n")
+	bext.SetTimeReference(12345)
+	ixml := &IXML{Scene: "1", TrackList: &IXMLTrackList{TrackCount: "3", Tracks: []*IXMLTrack{
+		{ChannelIndex: "1", InterleaveIndex: "1", Name: "Boom"},
+		{ChannelIndex: "2", InterleaveIndex: "3", Name: "Lav 2"},
+		{ChannelIndex: "3", InterleaveIndex: "2", Name: "Lav1"},
+	}}}
+	createTestPolyFile(t, path, EncodePCMFormatChunk(16, 1, 3, 48000, 288000, 6, 16), bext, ixml, data)
+
+	paths, err := SplitPolyFile(path, dir)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(paths), 3, "paths length")
+	assertEqual(t, filepath.Base(paths[0]), "poly_Boom.wav", "file name")
+	assertEqual(t, filepath.Base(paths[1]), "poly_Lav1.wav", "file name")
+	assertEqual(t, filepath.Base(paths[2]), "poly_Lav_2.wav", "file name")
+
+	file, _ := os.Open(paths[1])
+	defer file.Close()
+	container, _ := ReadRiff(file.Name(), file)
+	fr, err := NewFrameReader(file, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.Channels(), 1, "Channels")
+	assertEqual(t, fr.BlockAlign(), 2, "BlockAlign")
+	assertEqual(t, fr.NumFrames(), uint64(100), "NumFrames")
+
+	frame := make([]byte, 2)
+	fr.ReadFrames(10, 1, frame)
+
+	assertEqual(t, bytes.Equal(frame, data[10*6+2:10*6+4]), true, "frame")
+
+	monoBext, _ := readBext(file, container)
+
+	assertEqual(t, monoBext.TimeReference(), uint64(12345), "TimeReference")
+	assertEqual(t, monoBext.Description(), "sSCENE=1\r\nsTRK1=Lav1\r\n", "Description")
+
+	monoIXML, _ := readIXML(file, container)
+
+	assertEqual(t, monoIXML.Scene, "1", "Scene")
+	assertEqual(t, monoIXML.TrackList.TrackCount, "1", "TrackCount")
+	assertEqual(t, monoIXML.TrackList.Tracks[0].Name, "Lav1", "Name")
+	assertEqual(t, monoIXML.TrackList.Tracks[0].ChannelIndex, "3", "ChannelIndex")
+	assertEqual(t, monoIXML.TrackList.Tracks[0].InterleaveIndex, "1", "InterleaveIndex")
+
+	mergedPath := filepath.Join(dir, "merged.wav")
+	err = MergeMonoFiles(mergedPath, paths)
+
+	assertNil(t, err, "err")
+
+	merged, _ := os.Open(mergedPath)
+	defer merged.Close()
+	container, _ = ReadRiff(merged.Name(), merged)
+	fr, _ = NewFrameReader(merged, container)
+	mergedData := make([]byte, len(data))
+	fr.ReadFrames(0, 100, mergedData)
+
+	assertEqual(t, fr.Channels(), 3, "Channels")
+	assertEqual(t, bytes.Equal(mergedData, data), true, "data")
+
+	mergedBext, _ := readBext(merged, container)
+
+	assertEqual(t, mergedBext.TimeReference(), uint64(12345), "TimeReference")
+	assertEqual(t, mergedBext.Description(), "sSCENE=1\r\nsTRK1=Boom\r\nsTRK2=Lav1\r\nsTRK3=Lav 2\r\n", "Description")
+
+	mergedIXML, _ := readIXML(merged, container)
+
+	assertEqual(t, mergedIXML.TrackList.TrackCount, "3", "TrackCount")
+	assertEqual(t, mergedIXML.TrackList.Tracks[2].Name, "Lav 2", "Name")
+	assertEqual(t, mergedIXML.TrackList.Tracks[2].InterleaveIndex, "3", "InterleaveIndex")
+}
+
+func TestSplitPoly(t *testing.T) {
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), make([]byte, 40))
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	err := SplitPoly(reader, container, []io.WriteSeeker{})
+
+	assertNotNil(t, err, "err when writers do not match channels")
+
+	names, err := TrackNames(reader, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, len(names), 2, "names length")
+	assertEqual(t, names[1], "ch2", "name")
+}
+
+// createTestPolyFile writes a BWF with provided chunks to path.
+func createTestPolyFile(t *testing.T, path string, format *PCMFormat, bext *Bext, ixml *IXML, data []byte) {
+	file, err := os.Create(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+	w, _ := NewRiffWriter(file)
+	w.WriteChunk(format.Bytes())
+
+	if bext != nil {
+		w.WriteChunk(bext.Bytes())
+	}
+
+	if ixml != nil {
+		ixmlBytes, _ := ixml.Bytes()
+		w.WriteChunk(ixmlBytes)
+	}
+
+	cw, _ := w.CreateChunk(CreateFourCC(DATAID), uint32(len(data)))
+	cw.Write(data)
+	err = w.Close()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitPolyAiff(t *testing.T) {
+	dir := t.TempDir()
+	aiff := createTestAiff(EncodeCOMMChunk(18, 2, 2, 16, 44100, FourCC{}, ""), 0, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	reader := bytes.NewReader(aiff)
+	container, _ := ReadAiff("test", reader)
+	files := make([]*os.File, 2)
+	writers := make([]io.WriteSeeker, 2)
+
+	for i := range files {
+		files[i], _ = os.Create(filepath.Join(dir, fmt.Sprintf("mono%d.wav", i)))
+		defer files[i].Close()
+		writers[i] = files[i]
+	}
+
+	err := SplitPoly(reader, container, writers)
+
+	assertNil(t, err, "err")
+
+	files[1].Seek(0, io.SeekStart)
+	container, _ = ReadRiff(files[1].Name(), files[1])
+	fr, _ := NewFrameReader(files[1], container)
+	frames := make([]byte, 4)
+	fr.ReadFrames(0, 2, frames)
+
+	assertEqual(t, bytes.Equal(frames, []byte{4, 3, 8, 7}), true, "little-endian samples")
+
+	path := filepath.Join(dir, "poly.aiff")
+	os.WriteFile(path, aiff, 0644)
+	paths, err := SplitPolyFile(path, dir)
+
+	assertNil(t, err, "err of SplitPolyFile with AIFF")
+	assertEqual(t, len(paths), 2, "paths length")
+
+	aiff = createTestAiff(EncodeCOMMChunk(18, 2, 2, 16, 44100, CreateFourCC("ima4"), ""), 0, make([]byte, 8))
+	reader = bytes.NewReader(aiff)
+	container, _ = ReadAiff("test", reader)
+	err = SplitPoly(reader, container, writers)
+
+	assertNotNil(t, err, "err with compressed samples")
+}
+
+func TestMergeMonoTimeReference(t *testing.T) {
+	dir := t.TempDir()
+	format := EncodePCMFormatChunk(16, 1, 1, 48000, 96000, 2, 16)
+	paths := []string{filepath.Join(dir, "a.wav"), filepath.Join(dir, "b.wav")}
+
+	for i, path := range paths {
+		bext := &Bext{}
+		bext.SetTimeReference(uint64(1000 + i))
+		createTestPolyFile(t, path, format, bext, nil, make([]byte, 20))
+	}
+
+	err := MergeMonoFiles(filepath.Join(dir, "merged.wav"), paths)
+
+	assertNotNil(t, err, "err with differing time reference")
+
+	createTestPolyFile(t, paths[1], format, nil, nil, make([]byte, 20))
+	err = MergeMonoFiles(filepath.Join(dir, "merged.wav"), paths)
+
+	assertNotNil(t, err, "err with bext missing in one file")
+
+	createTestPolyFile(t, paths[0], format, nil, nil, make([]byte, 20))
+	err = MergeMonoFiles(filepath.Join(dir, "merged.wav"), paths)
+
+	assertNil(t, err, "err without bext")
+}
+
+func TestWaveDataSize(t *testing.T) {
+	size, err := waveDataSize(1000, 4)
+
+	assertNil(t, err, "err")
+	assertEqual(t, size, uint32(4000), "size")
+
+	_, err = waveDataSize(1<<31, 4)
+
+	assertNotNil(t, err, "err when exceeding max. chunk size")
+}
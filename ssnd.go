@@ -0,0 +1,151 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ssndFieldsSize is the byte size of offset and block size of chunk 'SSND'.
+const ssndFieldsSize = 8
+
+// SSND is AIFF / AIFF-C sound data chunk 'SSND'. The sample frames start offset bytes after
+// the offset and block size fields, blockSize is the alignment of the sample frames, usually 0.
+type SSND struct {
+	*Header
+	offset    uint32
+	blockSize uint32
+	data      []byte
+}
+
+// Offset is the number of bytes preceding the first sample frame.
+func (s *SSND) Offset() uint32 {
+	return s.offset
+}
+
+// BlockSize is the size of the blocks the sample frames are aligned to, 0 if not aligned.
+func (s *SSND) BlockSize() uint32 {
+	return s.blockSize
+}
+
+// Data returns the sample frames. Nil is returned for chunks read by ReadSSND.
+func (s *SSND) Data() []byte {
+	return s.data
+}
+
+// SetData sets the sample frames.
+func (s *SSND) SetData(value []byte) {
+	s.data = value
+}
+
+// DataPos is the absolute position of the first sample frame in the container.
+func (s *SSND) DataPos() uint32 {
+	return s.StartPos() + HeaderSizeBytes + ssndFieldsSize + s.offset
+}
+
+// DataSize is the byte size of the sample frames following offset.
+func (s *SSND) DataSize() uint32 {
+	if s.Size() < ssndFieldsSize || s.Size()-ssndFieldsSize < s.offset {
+		return 0
+	}
+
+	return s.Size() - ssndFieldsSize - s.offset
+}
+
+// String returns string represensation of chunk.
+func (s *SSND) String() string {
+	return fmt.Sprintf("Offset: %d\nBlock size: %d\nData position: %d\nData size: %d", s.offset, s.blockSize, s.DataPos(), s.DataSize())
+}
+
+// Bytes converts SSND to byte array. A new Header with id 'SSND' is created.
+//
+// Header size is set to real data size. A minimum amount of 16 bytes is returned.
+// chunk header - 8 bytes
+// offset - 4 bytes
+// block size - 4 bytes
+// padding to offset - offset bytes
+// sample frames - not restricted amount of bytes
+//
+// A padding byte is added if size is odd. This optional byte is not reflected in size.
+func (s *SSND) Bytes() []byte {
+	byteOrder := binary.BigEndian
+	data := make([]byte, ssndFieldsSize+s.offset)
+	byteOrder.PutUint32(data[0:4], s.offset)
+	byteOrder.PutUint32(data[4:8], s.blockSize)
+	data = append(data, s.data...)
+	header := EncodeChunkHeader(CreateFourCC(SSNDID), uint32(len(data)), byteOrder)
+	bytes := append(header.Bytes(), data...)
+
+	return pad(bytes)
+}
+
+// EncodeSSNDChunk returns encoded chunk 'SSND' containing provided sample frames, preceded by offset bytes.
+func EncodeSSNDChunk(offset, blockSize uint32, data []byte) *SSND {
+	header := EncodeChunkHeader(CreateFourCC(SSNDID), ssndFieldsSize+offset+uint32(len(data)), binary.BigEndian)
+
+	return &SSND{Header: header, offset: offset, blockSize: blockSize, data: data}
+}
+
+// DecodeSSNDChunk decodes provided byte array to SSND.
+//
+// Array content should be:
+// chunk header - 8 bytes
+// offset - 4 bytes
+// block size - 4 bytes (min. requirement for successful decoding)
+// padding to offset - offset bytes
+// sample frames - not restricted amount of bytes
+func DecodeSSNDChunk(data []byte) (*SSND, error) {
+	s, err := decodeSSNDFields(data, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	start := HeaderSizeBytes + ssndFieldsSize + s.offset
+	end := HeaderSizeBytes + s.Size()
+
+	if end > uint32(len(data)) {
+		end = uint32(len(data))
+	}
+
+	if start < end {
+		s.data = append([]byte{}, data[start:end]...)
+	}
+
+	return s, nil
+}
+
+// ReadSSND reads the chunk 'SSND' of provided container without the sample frames.
+// Use DataPos and DataSize to locate the sample frames.
+func ReadSSND(reader io.ReaderAt, container *Container) (*SSND, error) {
+	header, err := findHeader(container, SSNDID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, HeaderSizeBytes+ssndFieldsSize)
+	_, err = reader.ReadAt(data, int64(header.StartPos()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeSSNDFields(data, header.StartPos())
+}
+
+// decodeSSNDFields decodes header, offset and block size of chunk 'SSND' starting at startPos.
+func decodeSSNDFields(data []byte, startPos uint32) (*SSND, error) {
+	if len(data) < int(HeaderSizeBytes+ssndFieldsSize) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes+ssndFieldsSize)
+		return nil, errors.New(msg)
+	}
+
+	byteOrder := binary.BigEndian
+	s := &SSND{Header: decodeChunkHeader(data[:HeaderSizeBytes], startPos, byteOrder)}
+	s.offset = byteOrder.Uint32(data[HeaderSizeBytes : HeaderSizeBytes+4])
+	s.blockSize = byteOrder.Uint32(data[HeaderSizeBytes+4 : HeaderSizeBytes+8])
+
+	return s, nil
+}
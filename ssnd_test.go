@@ -0,0 +1,68 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSSND(t *testing.T) {
+	chunk := EncodeSSNDChunk(4, 0, []byte{1, 2, 3})
+
+	assertEqual(t, chunk.ID(), SSNDID, "ID")
+	assertEqual(t, chunk.Size(), uint32(15), "Size")
+	assertEqual(t, chunk.DataSize(), uint32(3), "DataSize")
+
+	data := chunk.Bytes()
+
+	assertEqual(t, len(data), 24, "Bytes length with padding")
+	assertEqual(t, binary.BigEndian.Uint32(data[8:12]), uint32(4), "Offset big endian")
+
+	decoded, err := DecodeSSNDChunk(data)
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Offset(), uint32(4), "Offset")
+	assertEqual(t, decoded.BlockSize(), uint32(0), "BlockSize")
+	assertEqual(t, decoded.DataPos(), uint32(20), "DataPos")
+	assertEqual(t, bytes.Equal(decoded.Data(), []byte{1, 2, 3}), true, "Data")
+
+	_, err = DecodeSSNDChunk(data[:15])
+
+	assertNotNil(t, err, "err with short data")
+}
+
+func TestWriterCreateSSNDChunk(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "test.aiff"))
+
+	assertNil(t, err, "err")
+	defer file.Close()
+
+	comm := EncodeCOMMChunk(18, 2, 3, 16, 44100, FourCC{}, "")
+	w, _ := NewAiffWriter(file, CreateFourCC("AIFF"))
+	w.Encode(comm)
+	cw, err := w.CreateSSNDChunk(comm, 64)
+
+	assertNil(t, err, "err")
+
+	_, err = cw.Write(make([]byte, 12))
+
+	assertNil(t, err, "err")
+	assertNil(t, w.Close(), "err on Close")
+
+	file.Seek(0, io.SeekStart)
+	container, _ := ReadAiff(file.Name(), file)
+	ssnd, err := ReadSSND(file, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, ssnd.BlockSize(), uint32(64), "BlockSize")
+	assertEqual(t, ssnd.DataPos()%64, uint32(0), "DataPos aligned")
+	assertEqual(t, ssnd.DataSize(), uint32(12), "DataSize")
+
+	fr, err := NewFrameReader(file, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, fr.NumFrames(), uint64(3), "NumFrames")
+}
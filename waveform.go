@@ -0,0 +1,337 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const (
+	// version of audiowaveform data written
+	waveformDatVersion = 2
+	// audiowaveform flag of 8 bit values
+	waveformDatFlag8Bit = 1
+	// byte size of blocks of values read at once
+	waveformDatBlockSize = 4096
+)
+
+// Waveform is a min. / max. peak overview of sound data for drawing a waveform.
+// Each pixel covers SamplesPerPixel sample frames, the last pixel may cover less.
+type Waveform struct {
+	SampleRate      int
+	SamplesPerPixel int
+	Channels        int
+	// Min contains the min. sample value of each pixel in range [-1, 1], interleaved by channel.
+	Min []float64
+	// Max contains the max. sample value of each pixel in range [-1, 1], interleaved by channel.
+	Max []float64
+	// PeakPosition is the sample frame of the max. absolute sample value.
+	PeakPosition uint64
+}
+
+// Length is the number of pixels.
+func (w *Waveform) Length() int {
+	if w.Channels == 0 {
+		return 0
+	}
+
+	return len(w.Min) / w.Channels
+}
+
+// String returns string represensation of waveform.
+func (w *Waveform) String() string {
+	return fmt.Sprintf("Sample rate: %d\nSamples per pixel: %d\nChannels: %d\nLength: %d", w.SampleRate, w.SamplesPerPixel, w.Channels, w.Length())
+}
+
+// GenerateWaveform generates the waveform of the sound data of provided container with samplesPerPixel sample frames per pixel.
+func GenerateWaveform(reader io.ReaderAt, container *Container, samplesPerPixel int) (*Waveform, error) {
+	if samplesPerPixel <= 0 {
+		return nil, errors.New("samples per pixel must be greater than 0")
+	}
+
+	fr, err := NewFrameReader(reader, container)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = fr.validateSamples()
+
+	if err != nil {
+		return nil, err
+	}
+
+	channels := fr.Channels()
+	w := &Waveform{SampleRate: fr.SampleRate(), SamplesPerPixel: samplesPerPixel, Channels: channels}
+	var pixel int
+	var pos uint64
+	var peak float64
+	samples := make([]float64, 0)
+
+	err = readFrameBlocks(fr, 0, fr.NumFrames(), func(block []byte, frames int) error {
+		if cap(samples) < frames*channels {
+			samples = make([]float64, frames*channels)
+		}
+
+		samples = samples[:frames*channels]
+		fr.decodeSamples(block, samples)
+
+		for f := 0; f < frames; f++ {
+			if pixel == 0 {
+				for c := 0; c < channels; c++ {
+					w.Min = append(w.Min, math.Inf(1))
+					w.Max = append(w.Max, math.Inf(-1))
+				}
+			}
+
+			offset := len(w.Min) - channels
+
+			for c, value := range samples[f*channels : (f+1)*channels] {
+				w.Min[offset+c] = math.Min(w.Min[offset+c], value)
+				w.Max[offset+c] = math.Max(w.Max[offset+c], value)
+
+				if math.Abs(value) > peak {
+					peak = math.Abs(value)
+					w.PeakPosition = pos
+				}
+			}
+
+			pos++
+			pixel = (pixel + 1) % samplesPerPixel
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// WriteDat writes the waveform in binary data format (version 2) of BBC audiowaveform.
+// Values are quantized to provided number of bits, 8 or 16.
+func (w *Waveform) WriteDat(writer io.Writer, bits int) error {
+	err := validateWaveformBits(bits)
+
+	if err != nil {
+		return err
+	}
+
+	var flags uint32
+
+	if bits == 8 {
+		flags = waveformDatFlag8Bit
+	}
+
+	fields := []interface{}{int32(waveformDatVersion), flags, int32(w.SampleRate), int32(w.SamplesPerPixel), uint32(w.Length()), int32(w.Channels)}
+
+	for _, f := range fields {
+		err := binary.Write(writer, binary.LittleEndian, f)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	values := w.quantize(bits)
+
+	if bits == 8 {
+		data := make([]int8, len(values))
+
+		for i, value := range values {
+			data[i] = int8(value)
+		}
+
+		return binary.Write(writer, binary.LittleEndian, data)
+	}
+
+	data := make([]int16, len(values))
+
+	for i, value := range values {
+		data[i] = int16(value)
+	}
+
+	return binary.Write(writer, binary.LittleEndian, data)
+}
+
+// WriteJSON writes the waveform in JSON data format (version 2) of BBC audiowaveform.
+// Values are quantized to provided number of bits, 8 or 16.
+func (w *Waveform) WriteJSON(writer io.Writer, bits int) error {
+	err := validateWaveformBits(bits)
+
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		Version         int   `json:"version"`
+		Channels        int   `json:"channels"`
+		SampleRate      int   `json:"sample_rate"`
+		SamplesPerPixel int   `json:"samples_per_pixel"`
+		Bits            int   `json:"bits"`
+		Length          int   `json:"length"`
+		Data            []int `json:"data"`
+	}{waveformDatVersion, w.Channels, w.SampleRate, w.SamplesPerPixel, bits, w.Length(), w.quantize(bits)}
+
+	return json.NewEncoder(writer).Encode(data)
+}
+
+// quantize returns min. and max. value of each pixel and channel as integers of provided number of bits.
+func (w *Waveform) quantize(bits int) []int {
+	scale := math.Pow(2, float64(bits-1))
+	values := make([]int, 0, 2*len(w.Min))
+	value := func(v float64) int {
+		return int(math.Max(-scale, math.Min(scale-1, math.Round(v*scale))))
+	}
+
+	for i := range w.Min {
+		values = append(values, value(w.Min[i]), value(w.Max[i]))
+	}
+
+	return values
+}
+
+// ReadDat reads a waveform in binary data format (version 1 or 2) of BBC audiowaveform.
+// An error is returned if channels, sample rate or samples per pixel of the header are not positive
+// or the data ends before length pixels are read.
+func ReadDat(reader io.Reader) (*Waveform, error) {
+	var version int32
+	var flags uint32
+	var sampleRate, samplesPerPixel int32
+	var length uint32
+	channels := int32(1)
+	fields := []interface{}{&version, &flags, &sampleRate, &samplesPerPixel, &length}
+
+	for _, f := range fields {
+		err := binary.Read(reader, binary.LittleEndian, f)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if version != 1 && version != 2 {
+		msg := fmt.Sprintf("unsupported version %d", version)
+		return nil, errors.New(msg)
+	}
+
+	if version == 2 {
+		err := binary.Read(reader, binary.LittleEndian, &channels)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if channels <= 0 || sampleRate <= 0 || samplesPerPixel <= 0 {
+		msg := fmt.Sprintf("invalid header: %d channels, sample rate %d, %d samples per pixel", channels, sampleRate, samplesPerPixel)
+		return nil, errors.New(msg)
+	}
+
+	w := &Waveform{SampleRate: int(sampleRate), SamplesPerPixel: int(samplesPerPixel), Channels: int(channels)}
+	bytesPerValue := uint64(2)
+	scale := float64(32768)
+
+	if flags&waveformDatFlag8Bit != 0 {
+		bytesPerValue = 1
+		scale = 128
+	}
+
+	// values are read in blocks so a length exceeding the input fails before the whole waveform is allocated
+	remaining := 2 * uint64(length) * uint64(channels) * bytesPerValue
+	buf := make([]byte, waveformDatBlockSize)
+
+	for remaining > 0 {
+		block := buf
+
+		if remaining < uint64(len(buf)) {
+			block = buf[:remaining]
+		}
+
+		_, err := io.ReadFull(reader, block)
+
+		if err != nil {
+			return nil, err
+		}
+
+		remaining -= uint64(len(block))
+
+		for i := 0; i < len(block); i += 2 * int(bytesPerValue) {
+			if bytesPerValue == 1 {
+				w.Min = append(w.Min, float64(int8(block[i]))/scale)
+				w.Max = append(w.Max, float64(int8(block[i+1]))/scale)
+			} else {
+				w.Min = append(w.Min, float64(int16(binary.LittleEndian.Uint16(block[i:])))/scale)
+				w.Max = append(w.Max, float64(int16(binary.LittleEndian.Uint16(block[i+2:])))/scale)
+			}
+		}
+	}
+
+	return w, nil
+}
+
+// Levl converts the waveform to peak envelope chunk 'levl' with positive and negative peak values of provided format.
+func (w *Waveform) Levl(format uint32, timestamp time.Time) (*Levl, error) {
+	maxPeak := float64(0xFFFF)
+
+	if format == LevlFormatUint8 {
+		maxPeak = 0xFF
+	}
+
+	peaks := make([]uint16, 0, 2*len(w.Min))
+	value := func(v float64) uint16 {
+		return uint16(math.Round(math.Max(0, math.Min(1, v)) * maxPeak))
+	}
+
+	for i := range w.Min {
+		peaks = append(peaks, value(w.Max[i]), value(-w.Min[i]))
+	}
+
+	return EncodeLevlChunk(format, 2, w.SamplesPerPixel, w.Channels, uint32(w.PeakPosition), timestamp, peaks)
+}
+
+// WaveformFromLevl converts peak envelope chunk 'levl' to a waveform of provided sample rate.
+// If only the positive peak is stored, the waveform is symmetric.
+func WaveformFromLevl(l *Levl, sampleRate int) *Waveform {
+	w := &Waveform{SampleRate: sampleRate, SamplesPerPixel: l.BlockSize(), Channels: l.PeakChannels()}
+	maxPeak := float64(l.MaxPeak())
+	points := l.PointsPerValue()
+	peaks := l.Peaks()
+
+	if points < 1 {
+		return w
+	}
+
+	for i := 0; i+points <= len(peaks); i += points {
+		positive := float64(peaks[i]) / maxPeak
+		negative := positive
+
+		if points == 2 {
+			negative = float64(peaks[i+1]) / maxPeak
+		}
+
+		w.Min = append(w.Min, -negative)
+		w.Max = append(w.Max, positive)
+	}
+
+	if l.PosPeakOfPeaks() != LevlPeakOfPeaksUnknown {
+		w.PeakPosition = uint64(l.PosPeakOfPeaks())
+	}
+
+	return w
+}
+
+func validateWaveformBits(bits int) error {
+	if bits != 8 && bits != 16 {
+		msg := fmt.Sprintf("unsupported number of bits %d", bits)
+		return errors.New(msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGenerateWaveform(t *testing.T) {
+	data := createTestSine(0.5, 441, 44100, 1000, 2)
+	riff := createTestRiff(EncodePCMFormatChunk(16, 1, 2, 44100, 176400, 4, 16), data)
+	reader := bytes.NewReader(riff)
+	container, _ := ReadRiff("test", reader)
+	w, err := GenerateWaveform(reader, container, 100)
+
+	assertNil(t, err, "err")
+	assertEqual(t, w.Length(), 10, "Length")
+	assertEqual(t, w.Channels, 2, "Channels")
+	assertEqual(t, math.Abs(w.Max[0]-0.5) < 1e-3, true, "Max")
+	assertEqual(t, math.Abs(w.Min[1]+0.5) < 1e-3, true, "Min")
+	assertEqual(t, w.PeakPosition, uint64(25), "PeakPosition")
+
+	w, _ = GenerateWaveform(reader, container, 300)
+
+	assertEqual(t, w.Length(), 4, "Length with partial pixel")
+
+	_, err = GenerateWaveform(reader, container, 0)
+
+	assertNotNil(t, err, "err with 0 samples per pixel")
+}
+
+func TestWaveformDat(t *testing.T) {
+	w := &Waveform{SampleRate: 48000, SamplesPerPixel: 256, Channels: 2, Min: []float64{-0.5, -1, 0, -0.25}, Max: []float64{0.5, 1, 0.25, 0}}
+
+	for _, bits := range []int{8, 16} {
+		buf := &bytes.Buffer{}
+		err := w.WriteDat(buf, bits)
+
+		assertNil(t, err, "err")
+		assertEqual(t, buf.Len(), 24+len(w.Min)*2*bits/8, "dat length")
+
+		read, err := ReadDat(buf)
+
+		assertNil(t, err, "err")
+		assertEqual(t, read.SampleRate, 48000, "SampleRate")
+		assertEqual(t, read.SamplesPerPixel, 256, "SamplesPerPixel")
+		assertEqual(t, read.Channels, 2, "Channels")
+		assertEqual(t, read.Min[1], -1., "Min")
+		assertEqual(t, read.Max[0], 0.5, "Max")
+		assertEqual(t, read.Max[2], 0.25, "Max")
+	}
+
+	err := w.WriteDat(&bytes.Buffer{}, 12)
+
+	assertNotNil(t, err, "err with unsupported bits")
+}
+
+func TestReadDatMalformed(t *testing.T) {
+	header := func(version, sampleRate, samplesPerPixel int32, length uint32, channels int32) *bytes.Buffer {
+		buf := &bytes.Buffer{}
+
+		for _, f := range []interface{}{version, uint32(0), sampleRate, samplesPerPixel, length, channels} {
+			binary.Write(buf, binary.LittleEndian, f)
+		}
+
+		return buf
+	}
+
+	_, err := ReadDat(header(3, 48000, 256, 1, 1))
+
+	assertNotNil(t, err, "err with unsupported version")
+
+	_, err = ReadDat(header(2, 48000, 256, 1, 0))
+
+	assertNotNil(t, err, "err with 0 channels")
+
+	_, err = ReadDat(header(2, -1, 256, 1, 1))
+
+	assertNotNil(t, err, "err with negative sample rate")
+
+	_, err = ReadDat(header(2, 48000, 0, 1, 1))
+
+	assertNotNil(t, err, "err with 0 samples per pixel")
+
+	_, err = ReadDat(header(2, 48000, 256, 0xFFFFFFFF, 0x7FFFFFFF))
+
+	assertNotNil(t, err, "err with length exceeding data")
+
+	_, err = ReadDat(bytes.NewReader(header(2, 48000, 256, 1, 1).Bytes()[:10]))
+
+	assertNotNil(t, err, "err with truncated header")
+}
+
+func TestWaveformJSON(t *testing.T) {
+	w := &Waveform{SampleRate: 44100, SamplesPerPixel: 512, Channels: 1, Min: []float64{-0.5}, Max: []float64{1}}
+	buf := &bytes.Buffer{}
+	err := w.WriteJSON(buf, 8)
+
+	assertNil(t, err, "err")
+
+	var data map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &data)
+
+	assertEqual(t, data["version"], 2., "version")
+	assertEqual(t, data["samples_per_pixel"], 512., "samples_per_pixel")
+	assertEqual(t, data["length"], 1., "length")
+	assertEqual(t, data["data"].([]interface{})[0], -64., "data")
+	assertEqual(t, data["data"].([]interface{})[1], 127., "data")
+}
+
+func TestWaveformLevl(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "in.wav")
+	createTestPolyFile(t, path, EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16), nil, nil, createTestSine(0.5, 441, 44100, 1000, 1))
+	in, _ := os.Open(path)
+	defer in.Close()
+	container, _ := ReadRiff(in.Name(), in)
+	w, _ := GenerateWaveform(in, container, 256)
+	levl, err := w.Levl(LevlFormatUint16, time.Now())
+
+	assertNil(t, err, "err")
+	assertEqual(t, levl.NumPeakFrames(), 4, "NumPeakFrames")
+	assertEqual(t, levl.PosPeakOfPeaks(), uint32(25), "PosPeakOfPeaks")
+
+	out, _ := os.Create(filepath.Join(dir, "out.wav"))
+	defer out.Close()
+	err = ReplaceChunks(out, in, container, levl.Bytes())
+
+	assertNil(t, err, "err")
+
+	out.Seek(0, 0)
+	container, _ = ReadRiff(out.Name(), out)
+	headers := container.FindHeaders(LEVLID)
+
+	assertEqual(t, len(headers), 1, "levl headers")
+
+	data, _ := ReadChunk(out, headers[0])
+	decoded, _ := DecodeLevlChunk(data)
+	read := WaveformFromLevl(decoded, 44100)
+
+	assertEqual(t, read.Length(), 4, "Length")
+	assertEqual(t, read.SamplesPerPixel, 256, "SamplesPerPixel")
+	assertEqual(t, math.Abs(read.Max[0]-w.Max[0]) < 1e-4, true, "Max")
+	assertEqual(t, math.Abs(read.Min[0]-w.Min[0]) < 1e-4, true, "Min")
+}
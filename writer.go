@@ -0,0 +1,248 @@
+package chunk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Writer writes chunks to a RIFF or AIFF / AIFF-C container.
+// The container header is written when the Writer is created, its size is updated on Close.
+type Writer struct {
+	ws        io.WriteSeeker
+	byteOrder binary.ByteOrder
+	pos       uint32
+	chunk     *chunkWriter
+	needsFVER bool
+	hasFVER   bool
+}
+
+// NewRiffWriter creates a Writer for a RIFF container of format 'WAVE'.
+func NewRiffWriter(ws io.WriteSeeker) (*Writer, error) {
+	return newWriter(ws, CreateFourCC("RIFF"), CreateFourCC("WAVE"), binary.LittleEndian)
+}
+
+// NewAiffWriter creates a Writer for an AIFF container of provided format ('AIFF' or 'AIFC').
+// For format 'AIFC' a chunk 'FVER' of AIFCVersion1 is written before the first chunk unless it is 'FVER'.
+func NewAiffWriter(ws io.WriteSeeker, format FourCC) (*Writer, error) {
+	w, err := newWriter(ws, CreateFourCC("FORM"), format, binary.BigEndian)
+
+	if err != nil {
+		return nil, err
+	}
+
+	w.needsFVER = format == CreateFourCC("AIFC")
+
+	return w, nil
+}
+
+func newWriter(ws io.WriteSeeker, id FourCC, format FourCC, byteOrder binary.ByteOrder) (*Writer, error) {
+	header := EncodeContainerHeader(id, FormatSizeBytes, format, byteOrder)
+	_, err := ws.Write(header.Bytes())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{ws: ws, byteOrder: byteOrder, pos: ContainerHeaderSizeBytes}, nil
+}
+
+// ByteOrder is the byte order of the container.
+func (w *Writer) ByteOrder() binary.ByteOrder {
+	return w.byteOrder
+}
+
+// WriteChunk writes a complete chunk including chunk header, as returned by the Bytes function of known chunks.
+// A padding byte is added if size is odd.
+func (w *Writer) WriteChunk(data []byte) error {
+	if len(data) < int(HeaderSizeBytes) {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes)
+		return errors.New(msg)
+	}
+
+	header := decodeChunkHeader(data, w.pos, w.byteOrder)
+
+	if uint32(len(data)) < HeaderSizeBytes+header.Size() {
+		msg := fmt.Sprintf("data slice requires a minimim lenght of %d", HeaderSizeBytes+header.Size())
+		return errors.New(msg)
+	}
+
+	var id FourCC
+	copy(id[:], data[:IDSizeBytes])
+	cw, err := w.CreateChunk(id, header.Size())
+
+	if err != nil {
+		return err
+	}
+
+	_, err = cw.Write(data[HeaderSizeBytes : HeaderSizeBytes+header.Size()])
+
+	return err
+}
+
+// Encode writes provided known chunk, see WriteChunk.
+func (w *Writer) Encode(chunk Encoder) error {
+	return w.WriteChunk(chunk.Bytes())
+}
+
+// CreateChunk writes a chunk header with provided id and size and returns an io.Writer for the chunk data.
+// Exactly size bytes must be written before the next chunk is created or the Writer is closed.
+// A padding byte is added if size is odd.
+func (w *Writer) CreateChunk(id FourCC, size uint32) (io.Writer, error) {
+	if id == CreateFourCC(FVERID) {
+		w.needsFVER = false
+		w.hasFVER = true
+	}
+
+	err := w.writeFVER()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.finishChunk()
+
+	if err != nil {
+		return nil, err
+	}
+
+	header := EncodeChunkHeader(id, size, w.byteOrder)
+	_, err = w.ws.Write(header.Bytes())
+
+	if err != nil {
+		return nil, err
+	}
+
+	w.chunk = &chunkWriter{w: w.ws, size: size}
+	w.pos += header.FullSize()
+
+	return w.chunk, nil
+}
+
+// CreateSSNDChunk writes a chunk 'SSND' header, offset and block size and returns an io.Writer for the
+// sample frames described by provided 'COMM', exactly SampleFrames of uncompressed sample frames must be written.
+// If blockSize is greater than 0, offset is chosen to align the first sample frame to blockSize in the container.
+func (w *Writer) CreateSSNDChunk(comm *COMM, blockSize uint32) (io.Writer, error) {
+	size := uint32(comm.SampleFrames()) * uint32(comm.Channels()) * uint32((comm.SampleSize()+7)/8)
+
+	return w.createSSNDChunk(size, blockSize)
+}
+
+func (w *Writer) createSSNDChunk(size uint32, blockSize uint32) (io.Writer, error) {
+	err := w.writeFVER()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.finishChunk()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var offset uint32
+
+	if blockSize > 0 {
+		offset = (blockSize - (w.pos+HeaderSizeBytes+ssndFieldsSize)%blockSize) % blockSize
+	}
+
+	cw, err := w.CreateChunk(CreateFourCC(SSNDID), ssndFieldsSize+offset+size)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]byte, ssndFieldsSize+offset)
+	w.byteOrder.PutUint32(fields[0:4], offset)
+	w.byteOrder.PutUint32(fields[4:8], blockSize)
+	_, err = cw.Write(fields)
+
+	return cw, err
+}
+
+// Close finishes the current chunk and updates the size of the container header.
+// The underlying io.WriteSeeker is not closed.
+func (w *Writer) Close() error {
+	err := w.writeFVER()
+
+	if err != nil {
+		return err
+	}
+
+	err = w.finishChunk()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.ws.Seek(int64(IDSizeBytes), io.SeekStart)
+
+	if err != nil {
+		return err
+	}
+
+	size := make([]byte, SizeSizeBytes)
+	w.byteOrder.PutUint32(size, w.pos-HeaderSizeBytes)
+	_, err = w.ws.Write(size)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.ws.Seek(int64(w.pos), io.SeekStart)
+
+	return err
+}
+
+// writeFVER writes the chunk 'FVER' required by AIFF-C if not yet written.
+func (w *Writer) writeFVER() error {
+	if !w.needsFVER {
+		return nil
+	}
+
+	w.needsFVER = false
+
+	return w.Encode(EncodeFVERChunk(AIFCVersion1))
+}
+
+func (w *Writer) finishChunk() error {
+	if w.chunk == nil {
+		return nil
+	}
+
+	cw := w.chunk
+	w.chunk = nil
+
+	if cw.written != cw.size {
+		msg := fmt.Sprintf("chunk data size is %d, want %d", cw.written, cw.size)
+		return errors.New(msg)
+	}
+
+	if cw.size%2 == 0 {
+		return nil
+	}
+
+	_, err := w.ws.Write([]byte{0})
+
+	return err
+}
+
+// chunkWriter limits writes to the size of a chunk.
+type chunkWriter struct {
+	w       io.Writer
+	size    uint32
+	written uint32
+}
+
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	if uint64(cw.written)+uint64(len(p)) > uint64(cw.size) {
+		return 0, errors.New("write exceeds chunk size")
+	}
+
+	n, err := cw.w.Write(p)
+	cw.written += uint32(n)
+
+	return n, err
+}
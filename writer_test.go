@@ -0,0 +1,93 @@
+package chunk
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "test.wav"))
+
+	assertNil(t, err, "err")
+	defer file.Close()
+
+	w, err := NewRiffWriter(file)
+
+	assertNil(t, err, "err")
+
+	format := EncodePCMFormatChunk(16, 1, 1, 44100, 88200, 2, 16)
+	err = w.WriteChunk(format.Bytes())
+
+	assertNil(t, err, "err")
+
+	err = w.WriteChunk(make([]byte, HeaderSizeBytes-1))
+
+	assertNotNil(t, err, "err with short chunk")
+
+	cw, err := w.CreateChunk(CreateFourCC(DATAID), 3)
+
+	assertNil(t, err, "err")
+
+	_, err = cw.Write([]byte{1, 2, 3, 4})
+
+	assertNotNil(t, err, "err when exceeding chunk size")
+
+	_, err = cw.Write([]byte{1, 2, 3})
+
+	assertNil(t, err, "err")
+
+	err = w.Close()
+
+	assertNil(t, err, "err")
+
+	file.Seek(0, io.SeekStart)
+	container, err := ReadRiff(file.Name(), file)
+
+	assertNil(t, err, "err")
+	assertEqual(t, container.Header.ID(), "RIFF", "ID")
+	assertEqual(t, container.Header.Format(), "WAVE", "Format")
+	assertEqual(t, container.Header.Size(), uint32(4+24+12), "Size")
+	assertEqual(t, len(container.Headers), 2, "headers length")
+	assertEqual(t, container.Headers[1].ID(), DATAID, "ID")
+	assertEqual(t, container.Headers[1].Size(), uint32(3), "Size")
+	assertEqual(t, container.Headers[1].HasPadding(), true, "HasPadding")
+
+	data, _ := ReadChunk(file, container.Headers[1])
+
+	assertEqual(t, bytes.Equal(data[HeaderSizeBytes:], []byte{1, 2, 3}), true, "data")
+}
+
+func TestWriterChunkSize(t *testing.T) {
+	file, _ := os.Create(filepath.Join(t.TempDir(), "test.wav"))
+	defer file.Close()
+
+	w, _ := NewRiffWriter(file)
+	cw, _ := w.CreateChunk(CreateFourCC(DATAID), 4)
+	cw.Write([]byte{1, 2})
+	err := w.Close()
+
+	assertNotNil(t, err, "err when chunk data is incomplete")
+}
+
+func TestWriterEncode(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "test.wav"))
+
+	assertNil(t, err, "err")
+	defer file.Close()
+
+	w, _ := NewRiffWriter(file)
+	err = w.Encode(EncodeAcidChunk(AcidOneShot, 0, 4, 4, 4, 90))
+
+	assertNil(t, err, "err")
+	assertNil(t, w.Close(), "err on Close")
+
+	file.Seek(0, io.SeekStart)
+	container, _ := ReadRiff(file.Name(), file)
+	chunks, err := DecodeChunks(file, container)
+
+	assertNil(t, err, "err")
+	assertEqual(t, chunks[0].(*Acid).Tempo(), float32(90), "Tempo")
+}
@@ -0,0 +1,834 @@
+package chunk
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// XMPNamespaceDC is the namespace of Dublin Core properties, prefix 'dc'.
+	XMPNamespaceDC = "http://purl.org/dc/elements/1.1/"
+	// XMPNamespaceDM is the namespace of XMP dynamic media properties, prefix 'xmpDM'.
+	XMPNamespaceDM = "http://ns.adobe.com/xmp/1.0/DynamicMedia/"
+	// XMPNamespaceXMP is the namespace of XMP basic properties, prefix 'xmp'.
+	XMPNamespaceXMP = "http://ns.adobe.com/xap/1.0/"
+	// rdfNamespace is the namespace of RDF elements, prefix 'rdf'.
+	rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	// xmlNamespace is the namespace bound to prefix 'xml'.
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+	// xmpMetaNamespace is the namespace of the root element x:xmpmeta.
+	xmpMetaNamespace = "adobe:ns:meta/"
+)
+
+// xmpPrefixes are the prefixes declared for namespaces of properties set without declaration.
+var xmpPrefixes = map[string]string{
+	XMPNamespaceDC:  "dc",
+	XMPNamespaceDM:  "xmpDM",
+	XMPNamespaceXMP: "xmp",
+	rdfNamespace:    "rdf",
+}
+
+// XMPMarker is a marker of a track of property xmpDM:Tracks. Times are in the time format of the track,
+// e.g. sample frames for frame rate 'f48000'.
+type XMPMarker struct {
+	Name      string
+	StartTime string
+	Duration  string
+	Comment   string
+	// Type is e.g. 'Cue', 'Index', 'Chapter' or 'Speech'.
+	Type string
+}
+
+// XMPTrack is a track of property xmpDM:Tracks containing markers.
+type XMPTrack struct {
+	Name string
+	// Type is e.g. 'Cue' or 'Speech'.
+	Type string
+	// FrameRate is the time format of the markers, e.g. 'f48000'.
+	FrameRate string
+	Markers   []*XMPMarker
+}
+
+// XMP is an Extensible Metadata Platform (XMP) packet in RDF/XML. All elements and attributes are preserved,
+// properties are read and written by namespace and name.
+type XMP struct {
+	root *xmpNode
+}
+
+// xmpNode is an XML element with namespace prefixes preserved as read.
+type xmpNode struct {
+	prefix   string
+	local    string
+	ns       string
+	attrs    []*xmpAttr
+	children []*xmpNode
+	text     string
+}
+
+// xmpAttr is an XML attribute with namespace prefix preserved as read.
+type xmpAttr struct {
+	prefix string
+	local  string
+	ns     string
+	value  string
+}
+
+// NewXMP returns an empty XMP packet with a single rdf:Description.
+func NewXMP() *XMP {
+	description := &xmpNode{prefix: "rdf", local: "Description", ns: rdfNamespace,
+		attrs: []*xmpAttr{{prefix: "rdf", local: "about", ns: rdfNamespace}}}
+	rdf := &xmpNode{prefix: "rdf", local: "RDF", ns: rdfNamespace, children: []*xmpNode{description},
+		attrs: []*xmpAttr{{prefix: "xmlns", local: "rdf", value: rdfNamespace}}}
+	root := &xmpNode{prefix: "x", local: "xmpmeta", ns: xmpMetaNamespace, children: []*xmpNode{rdf},
+		attrs: []*xmpAttr{{prefix: "xmlns", local: "x", value: xmpMetaNamespace}}}
+
+	return &XMP{root: root}
+}
+
+// DecodeXMP decodes provided XMP packet. The xpacket wrapper is optional.
+func DecodeXMP(data []byte) (*XMP, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimRight(data, "\x00")))
+	scopes := []map[string]string{{"xml": xmlNamespace}}
+	var stack []*xmpNode
+	var root *xmpNode
+
+	for {
+		token, err := decoder.RawToken()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			scope := make(map[string]string)
+
+			for k, v := range scopes[len(scopes)-1] {
+				scope[k] = v
+			}
+
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" {
+					scope[a.Name.Local] = a.Value
+				} else if a.Name.Space == "" && a.Name.Local == "xmlns" {
+					scope[""] = a.Value
+				}
+			}
+
+			scopes = append(scopes, scope)
+			n := &xmpNode{prefix: t.Name.Space, local: t.Name.Local, ns: scope[t.Name.Space]}
+
+			for _, a := range t.Attr {
+				attr := &xmpAttr{prefix: a.Name.Space, local: a.Name.Local, value: a.Value}
+
+				if a.Name.Space != "" && a.Name.Space != "xmlns" {
+					attr.ns = scope[a.Name.Space]
+				}
+
+				n.attrs = append(n.attrs, attr)
+			}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, errors.New("invalid XMP: unexpected end element")
+			}
+
+			n := stack[len(stack)-1]
+
+			if len(n.children) > 0 {
+				n.text = ""
+			}
+
+			stack = stack[:len(stack)-1]
+			scopes = scopes[:len(scopes)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("invalid XMP: no root element")
+	}
+
+	return &XMP{root: root}, nil
+}
+
+// Bytes encodes the XMP packet including xpacket wrapper.
+func (x *XMP) Bytes() []byte {
+	var sb strings.Builder
+	sb.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	x.root.write(&sb, "")
+	sb.WriteString("\n<?xpacket end=\"w\"?>")
+
+	return []byte(sb.String())
+}
+
+// String returns string represensation of XMP.
+func (x *XMP) String() string {
+	return string(x.Bytes())
+}
+
+// Property returns the value of the simple property of provided namespace and name. For arrays the first
+// item is returned, e.g. the default language of dc:title. An empty string is returned if not present.
+func (x *XMP) Property(ns, name string) string {
+	for _, d := range x.descriptions() {
+		if a := d.attr(ns, name); a != nil {
+			return a.value
+		}
+
+		if c := d.child(ns, name); c != nil {
+			return c.value()
+		}
+	}
+
+	return ""
+}
+
+// SetProperty sets the value of the simple property of provided namespace and name. For arrays the items are
+// replaced by a single item.
+func (x *XMP) SetProperty(ns, name, value string) {
+	for _, d := range x.descriptions() {
+		if a := d.attr(ns, name); a != nil {
+			a.value = value
+			return
+		}
+
+		if c := d.child(ns, name); c != nil {
+			if array := c.array(); array != nil {
+				array.children = []*xmpNode{x.item(array, value)}
+				return
+			}
+
+			c.children = nil
+			c.text = value
+			return
+		}
+	}
+
+	d := x.description()
+	d.children = append(d.children, x.element(d, ns, name, value))
+}
+
+// Array returns the items of the array property (rdf:Seq, rdf:Bag or rdf:Alt) of provided namespace and name.
+// A simple property is returned as single item.
+func (x *XMP) Array(ns, name string) []string {
+	for _, d := range x.descriptions() {
+		if a := d.attr(ns, name); a != nil {
+			return []string{a.value}
+		}
+
+		c := d.child(ns, name)
+
+		if c == nil {
+			continue
+		}
+
+		array := c.array()
+
+		if array == nil {
+			return []string{c.value()}
+		}
+
+		values := make([]string, len(array.children))
+
+		for i, li := range array.children {
+			values[i] = li.value()
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+// SetArray sets the items of the array property of provided namespace and name. The array type of an existing
+// property is kept, rdf:Seq is used for new properties.
+func (x *XMP) SetArray(ns, name string, values []string) {
+	arrayType := "Seq"
+
+	for _, d := range x.descriptions() {
+		if c := d.child(ns, name); c != nil && c.array() != nil {
+			arrayType = c.array().local
+		}
+
+		d.remove(ns, name)
+	}
+
+	d := x.description()
+	property := x.element(d, ns, name, "")
+	array := x.element(d, rdfNamespace, arrayType, "")
+
+	for _, v := range values {
+		array.children = append(array.children, x.item(array, v))
+	}
+
+	property.children = []*xmpNode{array}
+	d.children = append(d.children, property)
+}
+
+// Title is property dc:title in the default language.
+func (x *XMP) Title() string {
+	return x.Property(XMPNamespaceDC, "title")
+}
+
+// SetTitle sets property dc:title in the default language.
+func (x *XMP) SetTitle(value string) {
+	x.setLangAlt(XMPNamespaceDC, "title", value)
+}
+
+// Description is property dc:description in the default language.
+func (x *XMP) Description() string {
+	return x.Property(XMPNamespaceDC, "description")
+}
+
+// SetDescription sets property dc:description in the default language.
+func (x *XMP) SetDescription(value string) {
+	x.setLangAlt(XMPNamespaceDC, "description", value)
+}
+
+// Creators is property dc:creator.
+func (x *XMP) Creators() []string {
+	return x.Array(XMPNamespaceDC, "creator")
+}
+
+// SetCreators sets property dc:creator.
+func (x *XMP) SetCreators(values []string) {
+	x.SetArray(XMPNamespaceDC, "creator", values)
+}
+
+// Scene is property xmpDM:scene.
+func (x *XMP) Scene() string {
+	return x.Property(XMPNamespaceDM, "scene")
+}
+
+// SetScene sets property xmpDM:scene.
+func (x *XMP) SetScene(value string) {
+	x.SetProperty(XMPNamespaceDM, "scene", value)
+}
+
+// ShotName is property xmpDM:shotName.
+func (x *XMP) ShotName() string {
+	return x.Property(XMPNamespaceDM, "shotName")
+}
+
+// SetShotName sets property xmpDM:shotName.
+func (x *XMP) SetShotName(value string) {
+	x.SetProperty(XMPNamespaceDM, "shotName", value)
+}
+
+// TakeNumber is property xmpDM:takeNumber, 0 if not present or invalid.
+func (x *XMP) TakeNumber() int {
+	value, _ := strconv.Atoi(x.Property(XMPNamespaceDM, "takeNumber"))
+
+	return value
+}
+
+// SetTakeNumber sets property xmpDM:takeNumber.
+func (x *XMP) SetTakeNumber(value int) {
+	x.SetProperty(XMPNamespaceDM, "takeNumber", strconv.Itoa(value))
+}
+
+// LogComment is property xmpDM:logComment.
+func (x *XMP) LogComment() string {
+	return x.Property(XMPNamespaceDM, "logComment")
+}
+
+// SetLogComment sets property xmpDM:logComment.
+func (x *XMP) SetLogComment(value string) {
+	x.SetProperty(XMPNamespaceDM, "logComment", value)
+}
+
+// Tracks returns the tracks of property xmpDM:Tracks.
+func (x *XMP) Tracks() []*XMPTrack {
+	var tracks []*XMPTrack
+
+	for _, d := range x.descriptions() {
+		c := d.child(XMPNamespaceDM, "Tracks")
+
+		if c == nil || c.array() == nil {
+			continue
+		}
+
+		for _, li := range c.array().children {
+			track := &XMPTrack{
+				Name:      li.field(XMPNamespaceDM, "trackName"),
+				Type:      li.field(XMPNamespaceDM, "trackType"),
+				FrameRate: li.field(XMPNamespaceDM, "frameRate"),
+			}
+
+			if markers := li.fieldNode(XMPNamespaceDM, "markers"); markers != nil && markers.array() != nil {
+				for _, m := range markers.array().children {
+					track.Markers = append(track.Markers, m.marker())
+				}
+			}
+
+			tracks = append(tracks, track)
+		}
+	}
+
+	return tracks
+}
+
+// SetTracks sets property xmpDM:Tracks to provided tracks. Existing tracks are updated in place, fields and
+// attributes not covered by XMPTrack and XMPMarker, e.g. xmpDM:guid or xmpDM:cuePointParams, are preserved.
+// Existing markers are kept, only markers not present are added and markers no longer present removed.
+func (x *XMP) SetTracks(tracks []*XMPTrack) {
+	var d, bag *xmpNode
+	i := 0
+
+	for _, desc := range x.descriptions() {
+		c := desc.child(XMPNamespaceDM, "Tracks")
+
+		if c == nil || c.array() == nil {
+			continue
+		}
+
+		d, bag = desc, c.array()
+		children := bag.children[:0]
+
+		for _, li := range bag.children {
+			if i < len(tracks) {
+				x.updateTrack(d, li, tracks[i])
+				children = append(children, li)
+				i++
+			}
+		}
+
+		bag.children = children
+	}
+
+	if bag == nil {
+		d = x.description()
+		bag = x.element(d, rdfNamespace, "Bag", "")
+		property := x.element(d, XMPNamespaceDM, "Tracks", "")
+		property.children = []*xmpNode{bag}
+		d.children = append(d.children, property)
+	}
+
+	for ; i < len(tracks); i++ {
+		li := x.resource(d)
+		x.updateTrack(d, li, tracks[i])
+		bag.children = append(bag.children, li)
+	}
+}
+
+// updateTrack updates the fields of provided track resource of description d.
+func (x *XMP) updateTrack(d, li *xmpNode, track *XMPTrack) {
+	x.setField(d, li, "trackName", track.Name)
+	x.setField(d, li, "trackType", track.Type)
+	x.setField(d, li, "frameRate", track.FrameRate)
+	markers := li.fieldNode(XMPNamespaceDM, "markers")
+
+	if len(track.Markers) == 0 {
+		x.setField(d, li, "markers", "")
+		return
+	}
+
+	if markers == nil || markers.array() == nil {
+		x.setField(d, li, "markers", "")
+		markers = x.element(d, XMPNamespaceDM, "markers", "")
+		markers.children = []*xmpNode{x.element(d, rdfNamespace, "Seq", "")}
+		s := li.structure()
+		s.children = append(s.children, markers)
+	}
+
+	// unchanged markers are kept, changed markers are updated in place of markers no longer present
+	seq := markers.array()
+	used := make([]bool, len(seq.children))
+	items := make([]*xmpNode, len(track.Markers))
+
+	for j, m := range track.Markers {
+		for k, item := range seq.children {
+			if !used[k] && *item.marker() == *m {
+				used[k] = true
+				items[j] = item
+				break
+			}
+		}
+	}
+
+	k := 0
+
+	for j, m := range track.Markers {
+		if items[j] != nil {
+			continue
+		}
+
+		for k < len(used) && used[k] {
+			k++
+		}
+
+		if k < len(used) {
+			used[k] = true
+			items[j] = seq.children[k]
+		} else {
+			items[j] = x.resource(d)
+		}
+
+		x.setField(d, items[j], "name", m.Name)
+		x.setField(d, items[j], "startTime", m.StartTime)
+		x.setField(d, items[j], "duration", m.Duration)
+		x.setField(d, items[j], "comment", m.Comment)
+		x.setField(d, items[j], "type", m.Type)
+	}
+
+	seq.children = items
+}
+
+// setField sets the xmpDM field of provided structure in place, stored as attribute or element.
+// The field is removed if value is empty.
+func (x *XMP) setField(d, n *xmpNode, name, value string) {
+	s := n.structure()
+
+	if value == "" {
+		n.remove(XMPNamespaceDM, name)
+		s.remove(XMPNamespaceDM, name)
+		return
+	}
+
+	for _, node := range []*xmpNode{n, s} {
+		if a := node.attr(XMPNamespaceDM, name); a != nil {
+			a.value = value
+			return
+		}
+
+		if c := node.child(XMPNamespaceDM, name); c != nil {
+			if c.value() != value {
+				c.children = nil
+				c.text = value
+			}
+
+			return
+		}
+	}
+
+	s.children = append(s.children, x.element(d, XMPNamespaceDM, name, value))
+}
+
+// setLangAlt sets the default language item of the language alternative property of provided namespace and name.
+func (x *XMP) setLangAlt(ns, name, value string) {
+	for _, d := range x.descriptions() {
+		if d.attr(ns, name) != nil || d.child(ns, name) != nil {
+			x.SetProperty(ns, name, value)
+			return
+		}
+	}
+
+	d := x.description()
+	alt := x.element(d, rdfNamespace, "Alt", "")
+	alt.children = []*xmpNode{x.item(alt, value)}
+	property := x.element(d, ns, name, "")
+	property.children = []*xmpNode{alt}
+	d.children = append(d.children, property)
+}
+
+// resource returns an empty rdf:li with rdf:parseType 'Resource'.
+func (x *XMP) resource(d *xmpNode) *xmpNode {
+	li := x.element(d, rdfNamespace, "li", "")
+	li.attrs = append(li.attrs, &xmpAttr{prefix: x.prefix(d, rdfNamespace), local: "parseType", ns: rdfNamespace, value: "Resource"})
+
+	return li
+}
+
+// item returns an rdf:li of provided array with provided value, in the default language for rdf:Alt.
+func (x *XMP) item(array *xmpNode, value string) *xmpNode {
+	li := &xmpNode{prefix: array.prefix, local: "li", ns: rdfNamespace, text: value}
+
+	if array.local == "Alt" {
+		li.attrs = []*xmpAttr{{prefix: "xml", local: "lang", ns: xmlNamespace, value: "x-default"}}
+	}
+
+	return li
+}
+
+// element returns a new element of provided namespace and name, declaring the namespace at d if required.
+func (x *XMP) element(d *xmpNode, ns, name, value string) *xmpNode {
+	return &xmpNode{prefix: x.prefix(d, ns), local: name, ns: ns, text: value}
+}
+
+// prefix returns the prefix declared for provided namespace at d or its ancestors,
+// a new prefix is declared at d if not found.
+func (x *XMP) prefix(d *xmpNode, ns string) string {
+	for _, n := range x.path(d) {
+		for _, a := range n.attrs {
+			if a.prefix == "xmlns" && a.value == ns {
+				return a.local
+			}
+		}
+	}
+
+	prefix, ok := xmpPrefixes[ns]
+
+	if !ok {
+		prefix = "ns" + strconv.Itoa(len(d.attrs)+1)
+	}
+
+	d.attrs = append(d.attrs, &xmpAttr{prefix: "xmlns", local: prefix, value: ns})
+
+	return prefix
+}
+
+// path returns the elements from the root to provided node.
+func (x *XMP) path(target *xmpNode) []*xmpNode {
+	var find func(n *xmpNode) []*xmpNode
+	find = func(n *xmpNode) []*xmpNode {
+		if n == target {
+			return []*xmpNode{n}
+		}
+
+		for _, c := range n.children {
+			if p := find(c); p != nil {
+				return append([]*xmpNode{n}, p...)
+			}
+		}
+
+		return nil
+	}
+
+	return find(x.root)
+}
+
+// descriptions returns all rdf:Description elements of rdf:RDF.
+func (x *XMP) descriptions() []*xmpNode {
+	rdf := x.root.find(rdfNamespace, "RDF")
+
+	if rdf == nil {
+		return nil
+	}
+
+	var descriptions []*xmpNode
+
+	for _, c := range rdf.children {
+		if c.is(rdfNamespace, "Description") {
+			descriptions = append(descriptions, c)
+		}
+	}
+
+	return descriptions
+}
+
+// description returns the first rdf:Description, created if not present.
+func (x *XMP) description() *xmpNode {
+	if descriptions := x.descriptions(); len(descriptions) > 0 {
+		return descriptions[0]
+	}
+
+	rdf := x.root.find(rdfNamespace, "RDF")
+
+	if rdf == nil {
+		x.root = NewXMP().root
+		return x.description()
+	}
+
+	d := &xmpNode{prefix: rdf.prefix, local: "Description", ns: rdfNamespace,
+		attrs: []*xmpAttr{{prefix: rdf.prefix, local: "about", ns: rdfNamespace}}}
+	rdf.children = append(rdf.children, d)
+
+	return d
+}
+
+func (n *xmpNode) is(ns, local string) bool {
+	return n.ns == ns && n.local == local
+}
+
+// find returns n or the first descendant of provided namespace and name.
+func (n *xmpNode) find(ns, local string) *xmpNode {
+	if n.is(ns, local) {
+		return n
+	}
+
+	for _, c := range n.children {
+		if f := c.find(ns, local); f != nil {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func (n *xmpNode) child(ns, local string) *xmpNode {
+	for _, c := range n.children {
+		if c.is(ns, local) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (n *xmpNode) attr(ns, local string) *xmpAttr {
+	for _, a := range n.attrs {
+		if a.ns == ns && a.local == local {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// remove removes the attributes and child elements of provided namespace and name.
+func (n *xmpNode) remove(ns, local string) {
+	attrs := n.attrs[:0]
+
+	for _, a := range n.attrs {
+		if a.ns != ns || a.local != local {
+			attrs = append(attrs, a)
+		}
+	}
+
+	n.attrs = attrs
+	children := n.children[:0]
+
+	for _, c := range n.children {
+		if !c.is(ns, local) {
+			children = append(children, c)
+		}
+	}
+
+	n.children = children
+}
+
+// array returns the rdf:Seq, rdf:Bag or rdf:Alt of a property element, nil if not an array.
+func (n *xmpNode) array() *xmpNode {
+	for _, c := range n.children {
+		if c.ns == rdfNamespace && (c.local == "Seq" || c.local == "Bag" || c.local == "Alt") {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// value returns the text of a property element, the first item for arrays or the resource URI.
+func (n *xmpNode) value() string {
+	if array := n.array(); array != nil {
+		if len(array.children) == 0 {
+			return ""
+		}
+
+		return array.children[0].value()
+	}
+
+	if a := n.attr(rdfNamespace, "resource"); a != nil {
+		return a.value
+	}
+
+	return n.text
+}
+
+// fieldNode returns the element of a field of a structure, which is n or its rdf:Description.
+func (n *xmpNode) fieldNode(ns, local string) *xmpNode {
+	if c := n.child(ns, local); c != nil {
+		return c
+	}
+
+	if d := n.child(rdfNamespace, "Description"); d != nil {
+		return d.child(ns, local)
+	}
+
+	return nil
+}
+
+// structure returns the element holding the fields of a structure, which is n or its rdf:Description.
+func (n *xmpNode) structure() *xmpNode {
+	if d := n.child(rdfNamespace, "Description"); d != nil {
+		return d
+	}
+
+	return n
+}
+
+// marker returns the marker of a structure of property xmpDM:markers.
+func (n *xmpNode) marker() *XMPMarker {
+	return &XMPMarker{
+		Name:      n.field(XMPNamespaceDM, "name"),
+		StartTime: n.field(XMPNamespaceDM, "startTime"),
+		Duration:  n.field(XMPNamespaceDM, "duration"),
+		Comment:   n.field(XMPNamespaceDM, "comment"),
+		Type:      n.field(XMPNamespaceDM, "type"),
+	}
+}
+
+// field returns the value of a field of a structure, stored as attribute or element.
+func (n *xmpNode) field(ns, local string) string {
+	if a := n.attr(ns, local); a != nil {
+		return a.value
+	}
+
+	if d := n.child(rdfNamespace, "Description"); d != nil {
+		if a := d.attr(ns, local); a != nil {
+			return a.value
+		}
+	}
+
+	if c := n.fieldNode(ns, local); c != nil {
+		return c.value()
+	}
+
+	return ""
+}
+
+func (n *xmpNode) name() string {
+	if n.prefix == "" {
+		return n.local
+	}
+
+	return n.prefix + ":" + n.local
+}
+
+// write writes the element with indentation.
+func (n *xmpNode) write(sb *strings.Builder, indent string) {
+	sb.WriteString(indent + "<" + n.name())
+
+	for _, a := range n.attrs {
+		name := a.local
+
+		if a.prefix != "" {
+			name = a.prefix + ":" + a.local
+		}
+
+		sb.WriteString(" " + name + "=\"")
+		xml.EscapeText(sb, []byte(a.value))
+		sb.WriteString("\"")
+	}
+
+	if len(n.children) == 0 {
+		if n.text == "" {
+			sb.WriteString("/>")
+			return
+		}
+
+		sb.WriteString(">")
+		xml.EscapeText(sb, []byte(n.text))
+		sb.WriteString("</" + n.name() + ">")
+
+		return
+	}
+
+	sb.WriteString(">")
+
+	for _, c := range n.children {
+		sb.WriteString("\n")
+		c.write(sb, indent+" ")
+	}
+
+	sb.WriteString("\n" + indent + "</" + n.name() + ">")
+}
@@ -0,0 +1,140 @@
+package chunk
+
+import (
+	"strings"
+	"testing"
+)
+
+const testXMP = `<?xpacket begin="` + "\ufeff" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="Adobe XMP Core">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmpDM="http://ns.adobe.com/xmp/1.0/DynamicMedia/"
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    xmlns:custom="http://example.com/custom/"
+   xmpDM:scene="12"
+   xmpDM:takeNumber="3"
+   custom:keep="value">
+   <dc:title>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">Title &amp; more</rdf:li>
+    </rdf:Alt>
+   </dc:title>
+   <xmpDM:Tracks>
+    <rdf:Bag>
+     <rdf:li rdf:parseType="Resource">
+      <xmpDM:trackName>CuePoint Markers</xmpDM:trackName>
+      <xmpDM:frameRate>f48000</xmpDM:frameRate>
+      <xmpDM:markers>
+       <rdf:Seq>
+        <rdf:li xmpDM:startTime="48000" xmpDM:name="Marker 1"/>
+        <rdf:li>
+         <rdf:Description xmpDM:startTime="96000" xmpDM:duration="100">
+          <xmpDM:name>Marker 2</xmpDM:name>
+         </rdf:Description>
+        </rdf:li>
+       </rdf:Seq>
+      </xmpDM:markers>
+     </rdf:li>
+    </rdf:Bag>
+   </xmpDM:Tracks>
+   <custom:element>kept</custom:element>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`
+
+func TestDecodeXMP(t *testing.T) {
+	x, err := DecodeXMP([]byte(testXMP))
+
+	assertNil(t, err, "err")
+	assertEqual(t, x.Title(), "Title & more", "Title")
+	assertEqual(t, x.Scene(), "12", "Scene")
+	assertEqual(t, x.TakeNumber(), 3, "TakeNumber")
+	assertEqual(t, x.Property("http://example.com/custom/", "element"), "kept", "custom element")
+
+	tracks := x.Tracks()
+
+	assertEqual(t, len(tracks), 1, "tracks length")
+	assertEqual(t, tracks[0].Name, "CuePoint Markers", "track name")
+	assertEqual(t, tracks[0].FrameRate, "f48000", "FrameRate")
+	assertEqual(t, len(tracks[0].Markers), 2, "markers length")
+	assertEqual(t, tracks[0].Markers[0].Name, "Marker 1", "marker name as attribute")
+	assertEqual(t, tracks[0].Markers[1].Name, "Marker 2", "marker name in description")
+	assertEqual(t, tracks[0].Markers[1].Duration, "100", "marker duration")
+
+	_, err = DecodeXMP([]byte("no xml"))
+
+	assertNotNil(t, err, "err without root element")
+}
+
+func TestXMPSetProperties(t *testing.T) {
+	x, _ := DecodeXMP([]byte(testXMP))
+	x.SetTitle("New title")
+	x.SetScene("14")
+	x.SetTakeNumber(4)
+	x.SetShotName("Shot")
+	x.SetCreators([]string{"first", "second"})
+	x.SetTracks([]*XMPTrack{{Name: "Markers", FrameRate: "f44100", Markers: []*XMPMarker{{Name: "<cue>", StartTime: "10"}}}})
+
+	decoded, err := DecodeXMP(x.Bytes())
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Title(), "New title", "Title")
+	assertEqual(t, decoded.Scene(), "14", "Scene")
+	assertEqual(t, decoded.TakeNumber(), 4, "TakeNumber")
+	assertEqual(t, decoded.ShotName(), "Shot", "ShotName")
+	assertEqual(t, strings.Join(decoded.Creators(), ","), "first,second", "Creators")
+	assertEqual(t, decoded.Property("http://example.com/custom/", "keep"), "value", "custom attribute kept")
+	assertEqual(t, decoded.Property("http://example.com/custom/", "element"), "kept", "custom element kept")
+	assertEqual(t, len(decoded.Tracks()), 1, "tracks length")
+	assertEqual(t, decoded.Tracks()[0].Markers[0].Name, "<cue>", "marker name")
+}
+
+func TestXMPSetTracksInPlace(t *testing.T) {
+	data := strings.Replace(testXMP, `<rdf:li xmpDM:startTime="48000" xmpDM:name="Marker 1"/>`,
+		`<rdf:li xmpDM:startTime="48000" xmpDM:name="Marker 1" xmpDM:guid="guid-1" xmpDM:cuePointType="Navigation"/>`, 1)
+	data = strings.Replace(data, `<xmpDM:trackName>CuePoint Markers</xmpDM:trackName>`,
+		`<xmpDM:trackName>CuePoint Markers</xmpDM:trackName>
+      <xmpDM:trackGuid>track-guid</xmpDM:trackGuid>`, 1)
+	x, _ := DecodeXMP([]byte(data))
+	tracks := x.Tracks()
+	tracks[0].Name = "Markers"
+	tracks[0].Markers = append(tracks[0].Markers[:1], &XMPMarker{Name: "Marker 3", StartTime: "144000"})
+	x.SetTracks(tracks)
+
+	decoded, err := DecodeXMP(x.Bytes())
+
+	assertNil(t, err, "err")
+
+	decodedTracks := decoded.Tracks()
+
+	assertEqual(t, len(decodedTracks), 1, "tracks length")
+	assertEqual(t, decodedTracks[0].Name, "Markers", "track name")
+	assertEqual(t, decodedTracks[0].FrameRate, "f48000", "FrameRate")
+	assertEqual(t, len(decodedTracks[0].Markers), 2, "markers length")
+	assertEqual(t, *decodedTracks[0].Markers[0], XMPMarker{Name: "Marker 1", StartTime: "48000"}, "kept marker")
+	assertEqual(t, *decodedTracks[0].Markers[1], XMPMarker{Name: "Marker 3", StartTime: "144000"}, "changed marker")
+	assertEqual(t, strings.Contains(string(x.Bytes()), `xmpDM:guid="guid-1"`), true, "marker guid kept")
+	assertEqual(t, strings.Contains(string(x.Bytes()), `xmpDM:cuePointType="Navigation"`), true, "marker cuePointType kept")
+	assertEqual(t, strings.Contains(string(x.Bytes()), "<xmpDM:trackGuid>track-guid</xmpDM:trackGuid>"), true, "track field kept")
+
+	x.SetTracks(nil)
+
+	assertEqual(t, len(x.Tracks()), 0, "tracks removed")
+}
+
+func TestNewXMP(t *testing.T) {
+	x := NewXMP()
+	x.SetTitle("Title")
+	x.SetLogComment("comment")
+	x.SetProperty("http://example.com/other/", "value", "1")
+
+	decoded, err := DecodeXMP(x.Bytes())
+
+	assertNil(t, err, "err")
+	assertEqual(t, decoded.Title(), "Title", "Title")
+	assertEqual(t, decoded.LogComment(), "comment", "LogComment")
+	assertEqual(t, decoded.Property("http://example.com/other/", "value"), "1", "undeclared namespace")
+	assertEqual(t, strings.Contains(string(x.Bytes()), "xmlns:xmpDM="), true, "xmpDM declared")
+}